@@ -2,14 +2,11 @@ package client
 
 import (
 	"context"
-	"encoding/base64"
-	"encoding/json"
 	"fmt"
 	"io"
 	"net/http"
 	"net/url"
 	"path/filepath"
-	"strings"
 
 	"github.com/hyperhq/hyper-api/types"
 )
@@ -30,12 +27,8 @@ func (cli *Client) ContainerStatPath(ctx context.Context, containerID, path stri
 
 // CopyToContainer copies content into the container filesystem.
 func (cli *Client) CopyToContainer(ctx context.Context, container, path string, content io.Reader, options types.CopyToContainerOptions) error {
-	query := url.Values{}
+	query := options.ToQuery()
 	query.Set("path", filepath.ToSlash(path)) // Normalize the paths used in the API.
-	// Do not allow for an existing directory to be overwritten by a non-directory and vice versa.
-	if !options.AllowOverwriteDirWithFile {
-		query.Set("noOverwriteDirNonDir", "true")
-	}
 
 	apiPath := fmt.Sprintf("/containers/%s/archive", container)
 
@@ -82,15 +75,5 @@ func (cli *Client) CopyFromContainer(ctx context.Context, container, srcPath str
 }
 
 func getContainerPathStatFromHeader(header http.Header) (types.ContainerPathStat, error) {
-	var stat types.ContainerPathStat
-
-	encodedStat := header.Get("X-Docker-Container-Path-Stat")
-	statDecoder := base64.NewDecoder(base64.StdEncoding, strings.NewReader(encodedStat))
-
-	err := json.NewDecoder(statDecoder).Decode(&stat)
-	if err != nil {
-		err = fmt.Errorf("unable to decode container path stat header: %s", err)
-	}
-
-	return stat, err
+	return types.DecodePathStat(header.Get(types.PathStatHeader))
 }
@@ -2,46 +2,16 @@ package client
 
 import (
 	"encoding/json"
-	"net/url"
-	"strconv"
 
 	"context"
 	"github.com/hyperhq/hyper-api/types"
-	"github.com/hyperhq/hyper-api/types/filters"
 )
 
 // ContainerList returns the list of containers in the docker host.
 func (cli *Client) ContainerList(ctx context.Context, options types.ContainerListOptions) ([]types.Container, error) {
-	query := url.Values{}
-
-	if options.All {
-		query.Set("all", "1")
-	}
-
-	if options.Limit != -1 {
-		query.Set("limit", strconv.Itoa(options.Limit))
-	}
-
-	if options.Since != "" {
-		query.Set("since", options.Since)
-	}
-
-	if options.Before != "" {
-		query.Set("before", options.Before)
-	}
-
-	if options.Size {
-		query.Set("size", "1")
-	}
-
-	if options.Filter.Len() > 0 {
-		filterJSON, err := filters.ToParamWithVersion(cli.version, options.Filter)
-
-		if err != nil {
-			return nil, err
-		}
-
-		query.Set("filters", filterJSON)
+	query := options.ToQuery()
+	if err := setFiltersQuery(query, cli.version, options.Filter); err != nil {
+		return nil, err
 	}
 
 	resp, err := cli.get(ctx, "/containers/json", query, nil)
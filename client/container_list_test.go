@@ -37,9 +37,8 @@ func TestContainerList(t *testing.T) {
 			if all != "1" {
 				return nil, fmt.Errorf("all not set in URL query properly. Expected '1', got %s", all)
 			}
-			limit := query.Get("limit")
-			if limit != "0" {
-				return nil, fmt.Errorf("limit should have not be present in query. Expected '0', got %s", limit)
+			if limit, ok := query["limit"]; ok {
+				return nil, fmt.Errorf("limit should have not be present in query, got %s", limit)
 			}
 			since := query.Get("since")
 			if since != "container" {
@@ -94,3 +93,26 @@ func TestContainerList(t *testing.T) {
 		t.Fatalf("expected 2 containers, got %v", containers)
 	}
 }
+
+func TestContainerListLegacyFilters(t *testing.T) {
+	expectedFilters := `{"status":["running"]}`
+	client := &Client{
+		version: "1.21",
+		transport: newMockClient(nil, func(req *http.Request) (*http.Response, error) {
+			filters := req.URL.Query().Get("filters")
+			if filters != expectedFilters {
+				return nil, fmt.Errorf("expected filters incoherent '%v' with actual filters %v", expectedFilters, filters)
+			}
+			return &http.Response{
+				StatusCode: http.StatusOK,
+				Body:       ioutil.NopCloser(bytes.NewReader([]byte("[]"))),
+			}, nil
+		}),
+	}
+
+	filters := filters.NewArgs()
+	filters.Add("status", "running")
+	if _, err := client.ContainerList(context.Background(), types.ContainerListOptions{Filter: filters}); err != nil {
+		t.Fatal(err)
+	}
+}
@@ -41,7 +41,7 @@ func TestContainerLogs(t *testing.T) {
 	}{
 		{
 			expectedQueryParams: map[string]string{
-				"tail": "",
+				"tail": "all",
 			},
 		},
 		{
@@ -61,7 +61,7 @@ func TestContainerLogs(t *testing.T) {
 				Follow:     true,
 			},
 			expectedQueryParams: map[string]string{
-				"tail":       "",
+				"tail":       "all",
 				"stdout":     "1",
 				"stderr":     "1",
 				"timestamps": "1",
@@ -76,7 +76,7 @@ func TestContainerLogs(t *testing.T) {
 				Since: "invalid but valid",
 			},
 			expectedQueryParams: map[string]string{
-				"tail":  "",
+				"tail":  "all",
 				"since": "invalid but valid",
 			},
 		},
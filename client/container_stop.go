@@ -1,18 +1,21 @@
 package client
 
 import (
-	"net/url"
-	"strconv"
-
 	"context"
+
+	"github.com/hyperhq/hyper-api/types"
 )
 
 // ContainerStop stops a container without terminating the process.
 // The process is blocked until the container stops or the timeout expires.
 func (cli *Client) ContainerStop(ctx context.Context, containerID string, timeout int) error {
-	query := url.Values{}
-	query.Set("t", strconv.Itoa(timeout))
-	resp, err := cli.post(ctx, "/containers/"+containerID+"/stop", query, nil, nil)
+	return cli.ContainerStopWithOptions(ctx, containerID, types.ContainerStopOptions{Timeout: &timeout})
+}
+
+// ContainerStopWithOptions stops a container like ContainerStop. The
+// daemon default timeout is used when options.Timeout is nil.
+func (cli *Client) ContainerStopWithOptions(ctx context.Context, containerID string, options types.ContainerStopOptions) error {
+	resp, err := cli.post(ctx, "/containers/"+containerID+"/stop", options.ToQuery(), nil, nil)
 	ensureReaderClosed(resp)
 	return err
 }
@@ -2,7 +2,6 @@ package client
 
 import (
 	"encoding/json"
-	"net/url"
 	"strings"
 
 	"context"
@@ -12,10 +11,7 @@ import (
 // ContainerTop shows process information from within a container.
 func (cli *Client) ContainerTop(ctx context.Context, containerID string, arguments []string) (types.ContainerProcessList, error) {
 	var response types.ContainerProcessList
-	query := url.Values{}
-	if len(arguments) > 0 {
-		query.Set("ps_args", strings.Join(arguments, " "))
-	}
+	query := types.TopOptions{PsArgs: strings.Join(arguments, " ")}.ToQuery()
 
 	resp, err := cli.get(ctx, "/containers/"+containerID+"/top", query, nil)
 	if err != nil {
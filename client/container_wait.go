@@ -2,6 +2,8 @@ package client
 
 import (
 	"encoding/json"
+	"errors"
+	"net/url"
 
 	"context"
 
@@ -11,16 +13,43 @@ import (
 // ContainerWait pauses execution until a container exits.
 // It returns the API status code as response of its readiness.
 func (cli *Client) ContainerWait(ctx context.Context, containerID string) (int, error) {
-	resp, err := cli.post(ctx, "/containers/"+containerID+"/wait", nil, nil, nil)
+	res, err := cli.containerWait(ctx, containerID, nil)
 	if err != nil {
 		return -1, err
 	}
-	defer ensureReaderClosed(resp)
 
-	var res types.ContainerWaitResponse
-	if err := json.NewDecoder(resp.body).Decode(&res); err != nil {
+	return res.StatusCode, nil
+}
+
+// ContainerWaitWithOptions pauses execution until the container reaches
+// the condition in options, and returns its exit status code. Unlike
+// ContainerWait, it returns an error when the daemon reports that the
+// wait itself failed.
+func (cli *Client) ContainerWaitWithOptions(ctx context.Context, containerID string, options types.ContainerWaitOptions) (int, error) {
+	query, err := options.ToQuery()
+	if err != nil {
+		return -1, err
+	}
+
+	res, err := cli.containerWait(ctx, containerID, query)
+	if err != nil {
 		return -1, err
 	}
+	if res.Error != nil {
+		return res.StatusCode, errors.New(res.Error.Message)
+	}
 
 	return res.StatusCode, nil
 }
+
+func (cli *Client) containerWait(ctx context.Context, containerID string, query url.Values) (types.ContainerWaitResponse, error) {
+	var res types.ContainerWaitResponse
+	resp, err := cli.post(ctx, "/containers/"+containerID+"/wait", query, nil, nil)
+	if err != nil {
+		return res, err
+	}
+	defer ensureReaderClosed(resp)
+
+	err = json.NewDecoder(resp.body).Decode(&res)
+	return res, err
+}
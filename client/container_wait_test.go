@@ -58,6 +58,35 @@ func TestContainerWait(t *testing.T) {
 	}
 }
 
+func TestContainerWaitWithOptions(t *testing.T) {
+	client := &Client{
+		transport: newMockClient(nil, func(req *http.Request) (*http.Response, error) {
+			if condition := req.URL.Query().Get("condition"); condition != "removed" {
+				return nil, fmt.Errorf("condition not set in URL query properly. Expected 'removed', got %s", condition)
+			}
+			b, err := json.Marshal(types.ContainerWaitResponse{
+				StatusCode: 1,
+				Error:      &types.ContainerWaitError{Message: "container removal failed"},
+			})
+			if err != nil {
+				return nil, err
+			}
+			return &http.Response{
+				StatusCode: http.StatusOK,
+				Body:       ioutil.NopCloser(bytes.NewReader(b)),
+			}, nil
+		}),
+	}
+
+	code, err := client.ContainerWaitWithOptions(context.Background(), "container_id", types.ContainerWaitOptions{Condition: types.WaitConditionRemoved})
+	if err == nil || err.Error() != "container removal failed" {
+		t.Fatalf("expected the wait error, got %v", err)
+	}
+	if code != 1 {
+		t.Fatalf("expected a status code equal to '1', got %d", code)
+	}
+}
+
 func ExampleClient_ContainerWait_withTimeout() {
 	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
 	defer cancel()
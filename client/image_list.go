@@ -2,31 +2,17 @@ package client
 
 import (
 	"encoding/json"
-	"net/url"
 
 	"context"
 	"github.com/hyperhq/hyper-api/types"
-	"github.com/hyperhq/hyper-api/types/filters"
 )
 
 // ImageList returns a list of images in the docker host.
 func (cli *Client) ImageList(ctx context.Context, options types.ImageListOptions) ([]types.Image, error) {
 	var images []types.Image
-	query := url.Values{}
-
-	if options.Filters.Len() > 0 {
-		filterJSON, err := filters.ToParamWithVersion(cli.version, options.Filters)
-		if err != nil {
-			return images, err
-		}
-		query.Set("filters", filterJSON)
-	}
-	if options.MatchName != "" {
-		// FIXME rename this parameter, to not be confused with the filters flag
-		query.Set("filter", options.MatchName)
-	}
-	if options.All {
-		query.Set("all", "1")
+	query := options.ToQuery()
+	if err := setFiltersQuery(query, cli.version, options.Filters); err != nil {
+		return images, err
 	}
 
 	serverResp, err := cli.get(ctx, "/images/json", query, nil)
@@ -7,7 +7,6 @@ import (
 	"net/url"
 
 	"github.com/hyperhq/hyper-api/types"
-	"github.com/hyperhq/hyper-api/types/filters"
 	"github.com/hyperhq/hyper-api/types/registry"
 )
 
@@ -15,15 +14,10 @@ import (
 // The list of results is not sorted in any fashion.
 func (cli *Client) ImageSearch(ctx context.Context, term string, options types.ImageSearchOptions) ([]registry.SearchResult, error) {
 	var results []registry.SearchResult
-	query := url.Values{}
-	query.Set("term", term)
-
-	if options.Filters.Len() > 0 {
-		filterJSON, err := filters.ToParam(options.Filters)
-		if err != nil {
-			return results, err
-		}
-		query.Set("filters", filterJSON)
+	options.Term = term
+	query := options.ToQuery()
+	if err := setFiltersQuery(query, cli.version, options.Filters); err != nil {
+		return results, err
 	}
 
 	resp, err := cli.tryImageSearch(ctx, query, options.RegistryAuth)
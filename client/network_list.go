@@ -2,23 +2,16 @@ package client
 
 import (
 	"encoding/json"
-	"net/url"
 
 	"context"
 	"github.com/hyperhq/hyper-api/types"
-	"github.com/hyperhq/hyper-api/types/filters"
 )
 
 // NetworkList returns the list of networks configured in the docker host.
 func (cli *Client) NetworkList(ctx context.Context, options types.NetworkListOptions) ([]types.NetworkResource, error) {
-	query := url.Values{}
-	if options.Filters.Len() > 0 {
-		filterJSON, err := filters.ToParamWithVersion(cli.version, options.Filters)
-		if err != nil {
-			return nil, err
-		}
-
-		query.Set("filters", filterJSON)
+	query := options.ToQuery()
+	if err := setFiltersQuery(query, cli.version, options.Filters); err != nil {
+		return nil, err
 	}
 	var networkResources []types.NetworkResource
 	resp, err := cli.get(ctx, "/networks", query, nil)
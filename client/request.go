@@ -13,6 +13,7 @@ import (
 
 	"github.com/hyperhq/hyper-api/client/transport/cancellable"
 	"github.com/hyperhq/hyper-api/signature"
+	"github.com/hyperhq/hyper-api/types/filters"
 )
 
 // serverResponse is a wrapper for http API responses.
@@ -193,3 +194,19 @@ func isTimeout(err error) bool {
 	t, ok := e.(timeout)
 	return ok && t.Timeout()
 }
+
+// setFiltersQuery sets the "filters" query parameter if f is not empty, in
+// the format expected by daemons of the API version. It replaces the
+// filters encoded by the ToQuery methods of the options, which always use
+// the current format.
+func setFiltersQuery(query url.Values, version string, f filters.Args) error {
+	if f.Len() == 0 {
+		return nil
+	}
+	param, err := filters.ToParamWithVersion(version, f)
+	if err != nil {
+		return err
+	}
+	query.Set("filters", param)
+	return nil
+}
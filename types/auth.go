@@ -1,22 +1,39 @@
 package types
 
-// AuthConfig contains authorization information for connecting to a Registry
-type AuthConfig struct {
-	Username string `json:"username,omitempty"`
-	Password string `json:"password,omitempty"`
-	Auth     string `json:"auth,omitempty"`
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
 
-	// Email is an optional value associated with the username.
-	// This field is deprecated and will be removed in a later
-	// version of docker.
-	Email string `json:"email,omitempty"`
+	"github.com/hyperhq/hyper-api/types/registry"
+)
 
-	ServerAddress string `json:"serveraddress,omitempty"`
+// AuthConfig contains authorization information for connecting to a Registry.
+// It has the same fields as registry.AuthConfig and converts to it, e.g. to
+// be encoded with registry.EncodeAuthToBase64.
+type AuthConfig registry.AuthConfig
 
-	// IdentityToken is used to authenticate the user and get
-	// an access token for the registry.
-	IdentityToken string `json:"identitytoken,omitempty"`
+// EncodeAuthConfigs encodes the credentials of several registries, keyed by
+// registry address, as the URL-safe base64 JSON sent in the
+// X-Registry-Config header.
+func EncodeAuthConfigs(m map[string]AuthConfig) (string, error) {
+	buf, err := json.Marshal(m)
+	if err != nil {
+		return "", err
+	}
+	return base64.URLEncoding.EncodeToString(buf), nil
+}
 
-	// RegistryToken is a bearer token to be sent to a registry
-	RegistryToken string `json:"registrytoken,omitempty"`
+// DecodeAuthConfigs decodes the value of an X-Registry-Config header as
+// produced by EncodeAuthConfigs.
+func DecodeAuthConfigs(s string) (map[string]AuthConfig, error) {
+	buf, err := base64.URLEncoding.DecodeString(s)
+	if err != nil {
+		return nil, fmt.Errorf("invalid registry config encoding: %v", err)
+	}
+	m := map[string]AuthConfig{}
+	if err := json.Unmarshal(buf, &m); err != nil {
+		return nil, fmt.Errorf("invalid registry config: %v", err)
+	}
+	return m, nil
 }
@@ -0,0 +1,28 @@
+package types
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestAuthConfigsRoundTrip(t *testing.T) {
+	configs := map[string]AuthConfig{
+		"https://index.docker.io/v1/": {Username: "docker", Password: "hub"},
+		"registry.hyper.sh":           {Username: "hyper", IdentityToken: "token"},
+	}
+	encoded, err := EncodeAuthConfigs(configs)
+	if err != nil {
+		t.Fatal(err)
+	}
+	decoded, err := DecodeAuthConfigs(encoded)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(decoded, configs) {
+		t.Fatalf("expected %+v, got %+v", configs, decoded)
+	}
+
+	if _, err := DecodeAuthConfigs("not base64!"); err == nil {
+		t.Fatal("expected an error for an invalid encoding")
+	}
+}
@@ -0,0 +1,75 @@
+package types
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+)
+
+// BuildResult is a frame of the JSON stream returned by the build
+// endpoint. Exactly one of its fields is usually set.
+type BuildResult struct {
+	Stream string          `json:"stream,omitempty"`
+	Error  string          `json:"error,omitempty"`
+	Aux    *BuildResultAux `json:"aux,omitempty"`
+}
+
+// BuildResultAux holds the auxiliary data of a build frame, which is the
+// ID of the built image.
+type BuildResultAux struct {
+	ID string
+}
+
+// BuildResponseDecoder decodes the frames of the body of an
+// ImageBuildResponse.
+type BuildResponseDecoder struct {
+	dec     *json.Decoder
+	imageID string
+}
+
+// NewBuildResponseDecoder returns a BuildResponseDecoder reading from r.
+func NewBuildResponseDecoder(r io.Reader) *BuildResponseDecoder {
+	return &BuildResponseDecoder{dec: json.NewDecoder(r)}
+}
+
+// Decode reads the next frame into result. An error frame is returned as
+// an error, and io.EOF is returned at the end of the stream.
+func (d *BuildResponseDecoder) Decode(result *BuildResult) error {
+	*result = BuildResult{}
+	if err := d.dec.Decode(result); err != nil {
+		return err
+	}
+	if result.Error != "" {
+		return errors.New(result.Error)
+	}
+	if result.Aux != nil && result.Aux.ID != "" {
+		d.imageID = result.Aux.ID
+	}
+	return nil
+}
+
+// ImageID returns the ID of the built image, once the frame carrying it
+// has been decoded.
+func (d *BuildResponseDecoder) ImageID() string {
+	return d.imageID
+}
+
+// Run decodes the whole stream, copying the build output to w, and returns
+// the ID of the built image. It stops at the first error frame.
+func (d *BuildResponseDecoder) Run(w io.Writer) (string, error) {
+	var result BuildResult
+	for {
+		err := d.Decode(&result)
+		if err == io.EOF {
+			return d.imageID, nil
+		}
+		if err != nil {
+			return d.imageID, err
+		}
+		if result.Stream != "" {
+			if _, err := io.WriteString(w, result.Stream); err != nil {
+				return d.imageID, err
+			}
+		}
+	}
+}
@@ -0,0 +1,61 @@
+package types
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestBuildResponseDecoder(t *testing.T) {
+	stream := `{"stream":"Step 1/2 : FROM busybox\n"}
+{"stream":"Step 2/2 : RUN true\n"}
+{"aux":{"ID":"sha256:4a415e3663882fbc554ee830889c68a33b3585503892cc718a4698e91ef2a526"}}
+{"stream":"Successfully built 4a415e366388\n"}
+`
+	var out bytes.Buffer
+	id, err := NewBuildResponseDecoder(strings.NewReader(stream)).Run(&out)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if id != "sha256:4a415e3663882fbc554ee830889c68a33b3585503892cc718a4698e91ef2a526" {
+		t.Fatalf("unexpected image ID %q", id)
+	}
+	if expected := "Step 1/2 : FROM busybox\nStep 2/2 : RUN true\nSuccessfully built 4a415e366388\n"; out.String() != expected {
+		t.Fatalf("expected output %q, got %q", expected, out.String())
+	}
+}
+
+func TestBuildResponseDecoderError(t *testing.T) {
+	stream := `{"stream":"Step 1/2 : FROM busybox\n"}
+{"error":"The command '/bin/sh -c false' returned a non-zero code: 1","errorDetail":{"code":1}}
+{"stream":"never read\n"}
+`
+	var out bytes.Buffer
+	id, err := NewBuildResponseDecoder(strings.NewReader(stream)).Run(&out)
+	if err == nil || err.Error() != "The command '/bin/sh -c false' returned a non-zero code: 1" {
+		t.Fatalf("expected the error frame as an error, got %v", err)
+	}
+	if id != "" || strings.Contains(out.String(), "never read") {
+		t.Fatalf("expected the build to stop at the error, got %q, %q", id, out.String())
+	}
+}
+
+func TestImageBuildOptionsToQuery(t *testing.T) {
+	empty := ""
+	value := "1.0"
+	query, err := ImageBuildOptions{
+		Tags:      []string{"app:1.0", "app:latest"},
+		Remove:    true,
+		BuildArgs: map[string]*string{"UNSET": nil, "EMPTY": &empty, "VERSION": &value},
+		Size:      4,
+	}.ToQuery()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(query["t"]) != 2 || query.Get("rm") != "1" || query.Get("size") != "4" {
+		t.Fatalf("unexpected query: %v", query)
+	}
+	if expected := `{"EMPTY":"","UNSET":null,"VERSION":"1.0"}`; query.Get("buildargs") != expected {
+		t.Fatalf("expected build args %s, got %s", expected, query.Get("buildargs"))
+	}
+}
@@ -0,0 +1,36 @@
+package types
+
+import "fmt"
+
+// ChangeType is the kind of a change made to the filesystem of a
+// container. It is encoded as an integer.
+type ChangeType int
+
+// Kinds of filesystem changes.
+const (
+	// ChangeModify marks a modified path
+	ChangeModify ChangeType = iota
+	// ChangeAdd marks an added path
+	ChangeAdd
+	// ChangeDelete marks a deleted path
+	ChangeDelete
+)
+
+// String returns the letter "docker diff" uses for the kind of change,
+// "C", "A" or "D", or "?" for an unknown kind.
+func (t ChangeType) String() string {
+	switch t {
+	case ChangeModify:
+		return "C"
+	case ChangeAdd:
+		return "A"
+	case ChangeDelete:
+		return "D"
+	}
+	return "?"
+}
+
+// String formats the change as "docker diff" does, e.g. "A /tmp/file".
+func (c ContainerChange) String() string {
+	return fmt.Sprintf("%s %s", c.Kind, c.Path)
+}
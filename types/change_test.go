@@ -0,0 +1,40 @@
+package types
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestContainerChangeString(t *testing.T) {
+	testCases := []struct {
+		change   ContainerChange
+		expected string
+	}{
+		{ContainerChange{Kind: ChangeModify, Path: "/etc"}, "C /etc"},
+		{ContainerChange{Kind: ChangeAdd, Path: "/etc/hosts.new"}, "A /etc/hosts.new"},
+		{ContainerChange{Kind: ChangeDelete, Path: "/tmp/lock"}, "D /tmp/lock"},
+		{ContainerChange{Kind: 7, Path: "/x"}, "? /x"},
+	}
+	for _, c := range testCases {
+		if s := c.change.String(); s != c.expected {
+			t.Fatalf("expected %q, got %q", c.expected, s)
+		}
+	}
+}
+
+func TestContainerChangeJSON(t *testing.T) {
+	var changes []ContainerChange
+	if err := json.Unmarshal([]byte(`[{"Kind":1,"Path":"/a"},{"Kind":2,"Path":"/b"}]`), &changes); err != nil {
+		t.Fatal(err)
+	}
+	if changes[0].Kind != ChangeAdd || changes[1].Kind != ChangeDelete {
+		t.Fatalf("unexpected changes: %v", changes)
+	}
+	b, err := json.Marshal(changes[0])
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(b) != `{"Kind":1,"Path":"/a"}` {
+		t.Fatalf("expected the kind to be encoded as an integer, got %s", b)
+	}
+}
@@ -2,13 +2,19 @@ package types
 
 import (
 	"bufio"
+	"encoding/json"
+	"fmt"
 	"io"
 	"net"
 	"net/http"
+	"net/url"
+	"strconv"
+	"time"
 
 	"github.com/docker/go-units"
 	"github.com/hyperhq/hyper-api/types/container"
 	"github.com/hyperhq/hyper-api/types/filters"
+	timetypes "github.com/hyperhq/hyper-api/types/time"
 )
 
 // CheckpointCreateOptions holds parameters to create a checkpoint from a container
@@ -42,6 +48,17 @@ type ContainerExecInspect struct {
 	ContainerID string
 	Running     bool
 	ExitCode    int
+	Pid         int
+}
+
+// ExitStatus returns the exit code of the exec'd process. The boolean is
+// false while the process is still running, in which case the exit code
+// is meaningless.
+func (i ContainerExecInspect) ExitStatus() (int, bool) {
+	if i.Running {
+		return 0, false
+	}
+	return i.ExitCode, true
 }
 
 // ContainerListOptions holds parameters to list containers with.
@@ -56,17 +73,89 @@ type ContainerListOptions struct {
 	Filter filters.Args
 }
 
+// ToQuery encodes the options as the query parameters of the container
+// list endpoint. Only the options that are set are encoded: Quiet is
+// handled on the client side, Latest is sent as a limit of 1 when Limit is
+// not set and a Limit <= 0 is omitted.
+func (o ContainerListOptions) ToQuery() url.Values {
+	query := url.Values{}
+	if o.All {
+		query.Set("all", "1")
+	}
+	if o.Size {
+		query.Set("size", "1")
+	}
+	if o.Limit > 0 {
+		query.Set("limit", strconv.Itoa(o.Limit))
+	} else if o.Latest {
+		query.Set("limit", "1")
+	}
+	if o.Since != "" {
+		query.Set("since", o.Since)
+	}
+	if o.Before != "" {
+		query.Set("before", o.Before)
+	}
+	setFiltersQuery(query, o.Filter)
+	return query
+}
+
 // ContainerLogsOptions holds parameters to filter logs with.
 type ContainerLogsOptions struct {
 	ShowStdout bool
 	ShowStderr bool
 	Since      string
-	Timestamps bool
+	Until      string
+	Timestamps bool // Timestamps prefixes each line with its RFC3339Nano timestamp
 	Follow     bool
-	Tail       string
+	Tail       string // Tail is "all" or the number of lines to show from the end
 	Details    bool
 }
 
+// ToQuery encodes the options as the query parameters of the logs
+// endpoint. Since and Until can be timestamps or durations relative to now,
+// and an empty Tail shows all the lines; any other Tail is passed through
+// unchanged. An error is returned if Since or Until cannot be parsed.
+func (o ContainerLogsOptions) ToQuery() (url.Values, error) {
+	query := url.Values{}
+	if o.ShowStdout {
+		query.Set("stdout", "1")
+	}
+	if o.ShowStderr {
+		query.Set("stderr", "1")
+	}
+	ref := time.Now()
+	if o.Since != "" {
+		ts, err := timetypes.GetTimestamp(o.Since, ref)
+		if err != nil {
+			return nil, err
+		}
+		query.Set("since", ts)
+	}
+	if o.Until != "" {
+		ts, err := timetypes.GetTimestamp(o.Until, ref)
+		if err != nil {
+			return nil, err
+		}
+		query.Set("until", ts)
+	}
+	if o.Timestamps {
+		query.Set("timestamps", "1")
+	}
+	if o.Details {
+		query.Set("details", "1")
+	}
+	if o.Follow {
+		query.Set("follow", "1")
+	}
+	tail := o.Tail
+	if tail == "" {
+		tail = "all"
+	}
+	query.Set("tail", tail)
+	return query, nil
+}
+
 // ContainerRemoveOptions holds parameters to remove containers.
 type ContainerRemoveOptions struct {
 	RemoveVolumes bool
@@ -74,10 +163,27 @@ type ContainerRemoveOptions struct {
 	Force         bool
 }
 
+// ContainerRenameOptions holds parameters to rename a container.
+type ContainerRenameOptions struct {
+	Name string // Name is the new name of the container
+}
+
+// ToQuery encodes the options as the query parameters of the rename
+// endpoint, after checking the new name with ValidateContainerName.
+func (o ContainerRenameOptions) ToQuery() (url.Values, error) {
+	if err := ValidateContainerName(o.Name); err != nil {
+		return nil, err
+	}
+	query := url.Values{}
+	query.Set("name", o.Name)
+	return query, nil
+}
+
 // CopyToContainerOptions holds information
 // about files to copy into a container
 type CopyToContainerOptions struct {
 	AllowOverwriteDirWithFile bool
+	CopyUIDGID                bool // CopyUIDGID gives the copied files the ownership of the container user
 }
 
 // EventsOptions hold parameters to filter events with.
@@ -87,11 +193,43 @@ type EventsOptions struct {
 	Filters filters.Args
 }
 
+// ToQuery encodes the options as the query parameters of the events
+// endpoint. Since and Until can be timestamps or durations relative to now,
+// and an error is returned if they cannot be parsed.
+func (o EventsOptions) ToQuery() (url.Values, error) {
+	query := url.Values{}
+	ref := time.Now()
+	if o.Since != "" {
+		ts, err := timetypes.GetTimestamp(o.Since, ref)
+		if err != nil {
+			return nil, err
+		}
+		query.Set("since", ts)
+	}
+	if o.Until != "" {
+		ts, err := timetypes.GetTimestamp(o.Until, ref)
+		if err != nil {
+			return nil, err
+		}
+		query.Set("until", ts)
+	}
+	setFiltersQuery(query, o.Filters)
+	return query, nil
+}
+
 // NetworkListOptions holds parameters to filter the list of networks with.
 type NetworkListOptions struct {
 	Filters filters.Args
 }
 
+// ToQuery encodes the options as the query parameters of the network list
+// endpoint.
+func (o NetworkListOptions) ToQuery() url.Values {
+	query := url.Values{}
+	setFiltersQuery(query, o.Filters)
+	return query
+}
+
 // HijackedResponse holds connection information for a hijacked request.
 type HijackedResponse struct {
 	Conn   net.Conn
@@ -140,10 +278,78 @@ type ImageBuildOptions struct {
 	ShmSize        int64
 	Dockerfile     string
 	Ulimits        []*units.Ulimit
-	BuildArgs      map[string]string
+	BuildArgs      map[string]*string // A nil value passes the variable without a value, unlike an empty one
 	AuthConfigs    map[string]AuthConfig
 	Context        io.Reader
 	Labels         map[string]string
+	Size           int // Size of the build container, 0 for the default
+}
+
+// ToQuery encodes the options as the query parameters of the build
+// endpoint.
+func (options ImageBuildOptions) ToQuery() (url.Values, error) {
+	query := url.Values{
+		"t": options.Tags,
+	}
+	if options.SuppressOutput {
+		query.Set("q", "1")
+	}
+	if options.RemoteContext != "" {
+		query.Set("remote", options.RemoteContext)
+	}
+	if options.NoCache {
+		query.Set("nocache", "1")
+	}
+	if options.Remove {
+		query.Set("rm", "1")
+	} else {
+		query.Set("rm", "0")
+	}
+
+	if options.ForceRemove {
+		query.Set("forcerm", "1")
+	}
+
+	if options.PullParent {
+		query.Set("pull", "1")
+	}
+
+	if !container.Isolation.IsDefault(options.Isolation) {
+		query.Set("isolation", string(options.Isolation))
+	}
+
+	query.Set("cpusetcpus", options.CPUSetCPUs)
+	query.Set("cpusetmems", options.CPUSetMems)
+	query.Set("cpushares", strconv.FormatInt(options.CPUShares, 10))
+	query.Set("cpuquota", strconv.FormatInt(options.CPUQuota, 10))
+	query.Set("cpuperiod", strconv.FormatInt(options.CPUPeriod, 10))
+	query.Set("memory", strconv.FormatInt(options.Memory, 10))
+	query.Set("memswap", strconv.FormatInt(options.MemorySwap, 10))
+	query.Set("cgroupparent", options.CgroupParent)
+	query.Set("shmsize", strconv.FormatInt(options.ShmSize, 10))
+	query.Set("dockerfile", options.Dockerfile)
+	if options.Size > 0 {
+		query.Set("size", strconv.Itoa(options.Size))
+	}
+
+	ulimitsJSON, err := json.Marshal(options.Ulimits)
+	if err != nil {
+		return query, err
+	}
+	query.Set("ulimits", string(ulimitsJSON))
+
+	buildArgsJSON, err := json.Marshal(options.BuildArgs)
+	if err != nil {
+		return query, err
+	}
+	query.Set("buildargs", string(buildArgsJSON))
+
+	labelsJSON, err := json.Marshal(options.Labels)
+	if err != nil {
+		return query, err
+	}
+	query.Set("labels", string(labelsJSON))
+	return query, nil
 }
 
 // ImageBuildResponse holds information
@@ -179,6 +385,28 @@ type ImageListOptions struct {
 	Filters   filters.Args
 }
 
+// ToQuery encodes the options as the query parameters of the image list
+// endpoint, skipping the options that are not set.
+func (o ImageListOptions) ToQuery() url.Values {
+	query := url.Values{}
+	if o.All {
+		query.Set("all", "1")
+	}
+	if o.MatchName != "" {
+		query.Set("filter", o.MatchName)
+	}
+	setFiltersQuery(query, o.Filters)
+	return query
+}
+
+// setFiltersQuery sets the "filters" query parameter if f is not empty.
+func setFiltersQuery(query url.Values, f filters.Args) {
+	// Marshaling a map of strings cannot fail.
+	if param, _ := filters.ToParam(f); param != "" {
+		query.Set("filters", param)
+	}
+}
+
 // ImageLoadResponse returns information to the client about a load process.
 type ImageLoadResponse struct {
 	// Body must be closed to avoid a resource leak
@@ -218,11 +446,26 @@ type ImageRemoveOptions struct {
 
 // ImageSearchOptions holds parameters to search images with.
 type ImageSearchOptions struct {
+	Term          string
+	Limit         int
 	RegistryAuth  string
 	PrivilegeFunc RequestPrivilegeFunc
 	Filters       filters.Args
 }
 
+// ToQuery encodes the options as the query parameters of the image search
+// endpoint. The registry results can be narrowed with the "is-official"
+// and "is-automated" filters.
+func (o ImageSearchOptions) ToQuery() url.Values {
+	query := url.Values{}
+	query.Set("term", o.Term)
+	if o.Limit > 0 {
+		query.Set("limit", strconv.Itoa(o.Limit))
+	}
+	setFiltersQuery(query, o.Filters)
+	return query
+}
+
 // ImageTagOptions holds parameters to tag an image
 type ImageTagOptions struct {
 	Force bool
@@ -236,6 +479,68 @@ type ResizeOptions struct {
 	Width  int
 }
 
+// TopOptions holds parameters to list the processes running in a container.
+type TopOptions struct {
+	PsArgs string // PsArgs are the arguments passed to ps, e.g. "aux"
+}
+
+// ToQuery encodes the options as the query parameters of the top endpoint.
+func (o TopOptions) ToQuery() url.Values {
+	query := url.Values{}
+	if o.PsArgs != "" {
+		query.Set("ps_args", o.PsArgs)
+	}
+	return query
+}
+
+// ContainerStopOptions holds parameters to stop a container with.
+type ContainerStopOptions struct {
+	// Timeout is the number of seconds to wait for the container to stop
+	// before killing it. If nil, the daemon default is used.
+	Timeout *int
+}
+
+// ToQuery encodes the options as the query parameters of the stop endpoint.
+func (o ContainerStopOptions) ToQuery() url.Values {
+	query := url.Values{}
+	if o.Timeout != nil {
+		query.Set("t", strconv.Itoa(*o.Timeout))
+	}
+	return query
+}
+
+// Conditions a container can be waited for.
+const (
+	// WaitConditionNotRunning waits for the container to stop running
+	WaitConditionNotRunning = "not-running"
+	// WaitConditionNextExit waits for the next exit of the container,
+	// even if it is not running yet
+	WaitConditionNextExit = "next-exit"
+	// WaitConditionRemoved waits for the container to be removed
+	WaitConditionRemoved = "removed"
+)
+
+// ContainerWaitOptions holds parameters to wait for a container with.
+type ContainerWaitOptions struct {
+	// Condition is one of the WaitCondition constants. If empty, the
+	// daemon waits for the container to stop running.
+	Condition string
+}
+
+// ToQuery encodes the options as the query parameters of the wait
+// endpoint. It returns an error if the condition is unknown.
+func (o ContainerWaitOptions) ToQuery() (url.Values, error) {
+	query := url.Values{}
+	switch o.Condition {
+	case "":
+	case WaitConditionNotRunning, WaitConditionNextExit, WaitConditionRemoved:
+		query.Set("condition", o.Condition)
+	default:
+		return nil, fmt.Errorf("invalid wait condition %q", o.Condition)
+	}
+	return query, nil
+}
+
 // VersionResponse holds version information for the client and the server
 type VersionResponse struct {
 	Client *Version
@@ -0,0 +1,156 @@
+package types
+
+import (
+	"encoding/json"
+	"strconv"
+	"testing"
+
+	"github.com/hyperhq/hyper-api/types/filters"
+)
+
+func TestContainerListOptionsToQuery(t *testing.T) {
+	f := filters.NewArgs()
+	f.Add("status", "running")
+
+	testCases := []struct {
+		options  ContainerListOptions
+		expected string
+	}{
+		{ContainerListOptions{}, ""},
+		{ContainerListOptions{Quiet: true, Limit: -1}, ""},
+		{ContainerListOptions{Limit: 0}, ""},
+		{ContainerListOptions{All: true, Size: true, Limit: 5}, "all=1&limit=5&size=1"},
+		{ContainerListOptions{Latest: true}, "limit=1"},
+		{ContainerListOptions{Latest: true, Limit: 3}, "limit=3"},
+		{ContainerListOptions{Since: "abc", Before: "def"}, "before=def&since=abc"},
+		{ContainerListOptions{Filter: f}, "filters=%7B%22status%22%3A%7B%22running%22%3Atrue%7D%7D"},
+	}
+	for _, c := range testCases {
+		if query := c.options.ToQuery().Encode(); query != c.expected {
+			t.Fatalf("%+v: expected %q, got %q", c.options, c.expected, query)
+		}
+	}
+}
+
+func TestImageListOptionsToQuery(t *testing.T) {
+	if query := (ImageListOptions{}).ToQuery(); len(query) != 0 {
+		t.Fatalf("expected an empty query, got %v", query)
+	}
+	f := filters.NewArgs()
+	f.Add("dangling", "true")
+	query := ImageListOptions{All: true, MatchName: "busybox", Filters: f}.ToQuery()
+	if query.Get("all") != "1" || query.Get("filter") != "busybox" || query.Get("filters") != `{"dangling":{"true":true}}` {
+		t.Fatalf("unexpected query: %v", query)
+	}
+}
+
+func TestImageSearchOptionsToQuery(t *testing.T) {
+	if query := (ImageSearchOptions{Term: "nginx"}).ToQuery(); len(query) != 1 || query.Get("term") != "nginx" {
+		t.Fatalf("expected only the term, got %v", query)
+	}
+	f := filters.NewArgs()
+	f.Add("is-official", "true")
+	query := ImageSearchOptions{Term: "nginx", Limit: 10, Filters: f}.ToQuery()
+	if query.Get("limit") != "10" || query.Get("filters") != `{"is-official":{"true":true}}` {
+		t.Fatalf("unexpected query: %v", query)
+	}
+}
+
+func TestContainerLogsOptionsToQuery(t *testing.T) {
+	query, err := ContainerLogsOptions{}.ToQuery()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(query) != 1 || query.Get("tail") != "all" {
+		t.Fatalf("expected only tail=all, got %v", query)
+	}
+	query, err = ContainerLogsOptions{ShowStdout: true, Since: "1485000000", Until: "1485000600", Timestamps: true, Tail: "100"}.ToQuery()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if query.Get("stdout") != "1" || query.Get("stderr") != "" || query.Get("since") != "1485000000" || query.Get("until") != "1485000600" || query.Get("timestamps") != "1" || query.Get("tail") != "100" {
+		t.Fatalf("unexpected query: %v", query)
+	}
+	for _, o := range []ContainerLogsOptions{{Since: "2017-13-45"}, {Until: "2017-13-45"}} {
+		if _, err := o.ToQuery(); err == nil {
+			t.Fatalf("expected an error for %+v", o)
+		}
+	}
+}
+
+func TestContainerStopOptionsToQuery(t *testing.T) {
+	if query := (ContainerStopOptions{}).ToQuery(); len(query) != 0 {
+		t.Fatalf("expected an empty query, got %v", query)
+	}
+	for _, timeout := range []int{0, 30} {
+		timeout := timeout
+		query := ContainerStopOptions{Timeout: &timeout}.ToQuery()
+		if expected := strconv.Itoa(timeout); query.Get("t") != expected {
+			t.Fatalf("expected t=%s, got %v", expected, query)
+		}
+	}
+}
+
+func TestContainerWaitOptionsToQuery(t *testing.T) {
+	if query, err := (ContainerWaitOptions{}).ToQuery(); err != nil || len(query) != 0 {
+		t.Fatalf("expected an empty query, got %v, %v", query, err)
+	}
+	query, err := ContainerWaitOptions{Condition: WaitConditionRemoved}.ToQuery()
+	if err != nil || query.Get("condition") != "removed" {
+		t.Fatalf("expected condition=removed, got %v, %v", query, err)
+	}
+	if _, err := (ContainerWaitOptions{Condition: "exited"}).ToQuery(); err == nil {
+		t.Fatal("expected an error for an unknown condition")
+	}
+}
+
+func TestContainerWaitResponse(t *testing.T) {
+	var resp ContainerWaitResponse
+	if err := json.Unmarshal([]byte(`{"StatusCode": 137}`), &resp); err != nil {
+		t.Fatal(err)
+	}
+	if resp.StatusCode != 137 || resp.Error != nil {
+		t.Fatalf("unexpected response %+v", resp)
+	}
+	resp = ContainerWaitResponse{}
+	if err := json.Unmarshal([]byte(`{"StatusCode": -1, "Error": {"Message": "container is being removed"}}`), &resp); err != nil {
+		t.Fatal(err)
+	}
+	if resp.Error == nil || resp.Error.Message != "container is being removed" {
+		t.Fatalf("unexpected response %+v", resp)
+	}
+	if b, _ := json.Marshal(ContainerWaitResponse{StatusCode: 0}); string(b) != `{"StatusCode":0}` {
+		t.Fatalf("expected the error to be omitted, got %s", b)
+	}
+}
+
+func TestEventsOptionsToQuery(t *testing.T) {
+	f := filters.NewArgs()
+	f.Add("event", "die")
+	query, err := EventsOptions{Since: "1485000000", Until: "1485000600.5", Filters: f}.ToQuery()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if query.Get("since") != "1485000000" || query.Get("until") != "1485000600.5" || query.Get("filters") != `{"event":{"die":true}}` {
+		t.Fatalf("unexpected query: %v", query)
+	}
+
+	if query, err := (EventsOptions{}).ToQuery(); err != nil || len(query) != 0 {
+		t.Fatalf("expected an empty query, got %v, %v", query, err)
+	}
+	if _, err := (EventsOptions{Since: "2017-13-45"}).ToQuery(); err == nil {
+		t.Fatal("expected an error for an invalid since")
+	}
+}
+
+func TestContainerExecInspectExitStatus(t *testing.T) {
+	if _, done := (ContainerExecInspect{Running: true, Pid: 42}).ExitStatus(); done {
+		t.Fatal("expected a running exec not to have an exit status")
+	}
+	if code, done := (ContainerExecInspect{}).ExitStatus(); !done || code != 0 {
+		t.Fatalf("expected exit status 0, got %d, %v", code, done)
+	}
+	if code, done := (ContainerExecInspect{ExitCode: 3}).ExitStatus(); !done || code != 3 {
+		t.Fatalf("expected exit status 3, got %d, %v", code, done)
+	}
+}
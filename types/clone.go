@@ -0,0 +1,118 @@
+package types
+
+import (
+	"github.com/docker/go-connections/nat"
+	"github.com/hyperhq/hyper-api/types/network"
+)
+
+// Clone returns a deep copy of the inspect result, sharing no slice, map
+// or pointer with the original.
+func (c *ContainerJSON) Clone() *ContainerJSON {
+	if c == nil {
+		return nil
+	}
+	clone := &ContainerJSON{
+		ContainerJSONBase: c.ContainerJSONBase.Clone(),
+		Config:            c.Config.Clone(),
+		NetworkSettings:   c.NetworkSettings.Clone(),
+	}
+	if c.Mounts != nil {
+		clone.Mounts = append([]MountPoint(nil), c.Mounts...)
+	}
+	return clone
+}
+
+// Clone returns a deep copy of the base inspect result.
+func (b *ContainerJSONBase) Clone() *ContainerJSONBase {
+	if b == nil {
+		return nil
+	}
+	clone := *b
+	if b.Args != nil {
+		clone.Args = append([]string(nil), b.Args...)
+	}
+	if b.State != nil {
+		state := *b.State
+		if b.State.Health != nil {
+			health := *b.State.Health
+			if health.Log != nil {
+				health.Log = append([]HealthcheckResult(nil), health.Log...)
+			}
+			state.Health = &health
+		}
+		clone.State = &state
+	}
+	if b.Node != nil {
+		node := *b.Node
+		if b.Node.Labels != nil {
+			node.Labels = make(map[string]string, len(b.Node.Labels))
+			for k, v := range b.Node.Labels {
+				node.Labels[k] = v
+			}
+		}
+		clone.Node = &node
+	}
+	if b.ExecIDs != nil {
+		clone.ExecIDs = append([]string(nil), b.ExecIDs...)
+	}
+	clone.HostConfig = b.HostConfig.Clone()
+	if b.GraphDriver.Data != nil {
+		clone.GraphDriver.Data = make(map[string]string, len(b.GraphDriver.Data))
+		for k, v := range b.GraphDriver.Data {
+			clone.GraphDriver.Data[k] = v
+		}
+	}
+	if b.SizeRw != nil {
+		size := *b.SizeRw
+		clone.SizeRw = &size
+	}
+	if b.SizeRootFs != nil {
+		size := *b.SizeRootFs
+		clone.SizeRootFs = &size
+	}
+	return &clone
+}
+
+// Clone returns a deep copy of the network settings.
+func (s *NetworkSettings) Clone() *NetworkSettings {
+	if s == nil {
+		return nil
+	}
+	clone := *s
+	if s.Ports != nil {
+		clone.Ports = make(nat.PortMap, len(s.Ports))
+		for p, bindings := range s.Ports {
+			if bindings != nil {
+				bindings = append([]nat.PortBinding(nil), bindings...)
+			}
+			clone.Ports[p] = bindings
+		}
+	}
+	if s.SecondaryIPAddresses != nil {
+		clone.SecondaryIPAddresses = append([]network.Address(nil), s.SecondaryIPAddresses...)
+	}
+	if s.SecondaryIPv6Addresses != nil {
+		clone.SecondaryIPv6Addresses = append([]network.Address(nil), s.SecondaryIPv6Addresses...)
+	}
+	if s.Networks != nil {
+		clone.Networks = make(map[string]*network.EndpointSettings, len(s.Networks))
+		for name, ep := range s.Networks {
+			if ep != nil {
+				e := *ep
+				if ep.IPAMConfig != nil {
+					ipam := *ep.IPAMConfig
+					e.IPAMConfig = &ipam
+				}
+				if ep.Links != nil {
+					e.Links = append([]string(nil), ep.Links...)
+				}
+				if ep.Aliases != nil {
+					e.Aliases = append([]string(nil), ep.Aliases...)
+				}
+				ep = &e
+			}
+			clone.Networks[name] = ep
+		}
+	}
+	return &clone
+}
@@ -0,0 +1,96 @@
+package types
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/docker/go-connections/nat"
+	"github.com/docker/go-units"
+	"github.com/hyperhq/hyper-api/types/container"
+	"github.com/hyperhq/hyper-api/types/network"
+)
+
+func newCloneSource() *ContainerJSON {
+	swappiness := int64(60)
+	size := int64(1024)
+	return &ContainerJSON{
+		ContainerJSONBase: &ContainerJSONBase{
+			ID:      "abc",
+			Args:    []string{"-g", "daemon off;"},
+			State:   &ContainerState{Running: true, Health: &Health{Status: Healthy, Log: []HealthcheckResult{{ExitCode: 0}}}},
+			Node:    &ContainerNode{Labels: map[string]string{"zone": "a"}},
+			ExecIDs: []string{"exec1"},
+			HostConfig: &container.HostConfig{
+				Binds:        []string{"/data:/data"},
+				PortBindings: nat.PortMap{"80/tcp": {{HostPort: "8080"}}},
+				Sysctls:      map[string]string{"net.core.somaxconn": "1024"},
+				Resources: container.Resources{
+					MemorySwappiness: &swappiness,
+					Ulimits:          []*units.Ulimit{{Name: "nofile", Soft: 1024, Hard: 2048}},
+				},
+			},
+			GraphDriver: GraphDriverData{Data: map[string]string{"dir": "/var/lib"}},
+			SizeRw:      &size,
+		},
+		Mounts: []MountPoint{{Name: "vol", Destination: "/data"}},
+		Config: &container.Config{
+			Env:          []string{"A=1"},
+			Cmd:          []string{"nginx"},
+			Healthcheck:  &container.HealthConfig{Test: []string{"CMD", "curl", "-f", "localhost"}},
+			Labels:       map[string]string{"app": "web"},
+			ExposedPorts: map[nat.Port]struct{}{"80/tcp": {}},
+		},
+		NetworkSettings: &NetworkSettings{
+			NetworkSettingsBase: NetworkSettingsBase{Ports: nat.PortMap{"80/tcp": {{HostPort: "8080"}}}},
+			Networks: map[string]*network.EndpointSettings{
+				"bridge": {IPAddress: "172.17.0.2", Aliases: []string{"web"}, IPAMConfig: &network.EndpointIPAMConfig{IPv4Address: "172.17.0.2"}},
+			},
+		},
+	}
+}
+
+func TestContainerJSONClone(t *testing.T) {
+	original := newCloneSource()
+	clone := original.Clone()
+	if !reflect.DeepEqual(original, clone) {
+		t.Fatalf("expected the clone to equal the original")
+	}
+
+	clone.Args[0] = "-c"
+	clone.State.Running = false
+	clone.State.Health.Log[0].ExitCode = 1
+	clone.Node.Labels["zone"] = "b"
+	clone.ExecIDs[0] = "exec2"
+	clone.HostConfig.Binds[0] = "/tmp:/tmp"
+	clone.HostConfig.PortBindings["80/tcp"][0].HostPort = "9090"
+	clone.HostConfig.Sysctls["net.core.somaxconn"] = "4096"
+	*clone.HostConfig.MemorySwappiness = 0
+	clone.HostConfig.Ulimits[0].Soft = 1
+	clone.GraphDriver.Data["dir"] = "/tmp"
+	*clone.SizeRw = 0
+	clone.Mounts[0].Name = "other"
+	clone.Config.Env[0] = "A=2"
+	clone.Config.Cmd[0] = "sh"
+	clone.Config.Healthcheck.Test[0] = "CMD-SHELL"
+	clone.Config.Labels["app"] = "db"
+	delete(clone.Config.ExposedPorts, "80/tcp")
+	clone.NetworkSettings.Ports["80/tcp"][0].HostPort = "9090"
+	clone.NetworkSettings.Networks["bridge"].IPAddress = "172.17.0.3"
+	clone.NetworkSettings.Networks["bridge"].Aliases[0] = "db"
+	clone.NetworkSettings.Networks["bridge"].IPAMConfig.IPv4Address = "172.17.0.3"
+	clone.NetworkSettings.Networks["host"] = &network.EndpointSettings{}
+
+	if expected := newCloneSource(); !reflect.DeepEqual(original, expected) {
+		t.Fatalf("expected the original to be left untouched, got %+v", original)
+	}
+}
+
+func TestCloneNil(t *testing.T) {
+	if (*ContainerJSON)(nil).Clone() != nil {
+		t.Fatal("expected a nil clone")
+	}
+	clone := (&ContainerJSON{}).Clone()
+	if clone.ContainerJSONBase != nil || clone.Config != nil || clone.NetworkSettings != nil || clone.Mounts != nil {
+		t.Fatalf("expected an empty clone, got %+v", clone)
+	}
+}
@@ -0,0 +1,88 @@
+package types
+
+import (
+	"strings"
+
+	"github.com/hyperhq/hyper-api/types/container"
+)
+
+// EffectiveCmdLine returns the argv a container created from cfg would run,
+// which is the Entrypoint followed by the Cmd. A non-empty override replaces
+// the Cmd, the same way arguments given to `run` after the image name do.
+func EffectiveCmdLine(cfg *container.Config, override []string) []string {
+	var entrypoint, cmd []string
+	if cfg != nil {
+		entrypoint = cfg.Entrypoint
+		cmd = cfg.Cmd
+	}
+	if len(override) > 0 {
+		cmd = override
+	}
+
+	argv := make([]string, 0, len(entrypoint)+len(cmd))
+	argv = append(argv, entrypoint...)
+	return append(argv, cmd...)
+}
+
+// ShellQuote joins args into a command line that a POSIX shell splits back
+// into args. Arguments holding characters special to the shell are single
+// quoted, including the empty argument.
+func ShellQuote(args []string) string {
+	quoted := make([]string, len(args))
+	for i, arg := range args {
+		quoted[i] = shellQuoteArg(arg)
+	}
+	return strings.Join(quoted, " ")
+}
+
+func shellQuoteArg(arg string) string {
+	if arg == "" {
+		return "''"
+	}
+	safe := true
+	for _, r := range arg {
+		if !isShellSafe(r) {
+			safe = false
+			break
+		}
+	}
+	if safe {
+		return arg
+	}
+	// A single quote cannot appear within single quotes, so it is written
+	// by closing the quoted string, escaping it and reopening it.
+	return "'" + strings.Replace(arg, "'", `'\''`, -1) + "'"
+}
+
+func isShellSafe(r rune) bool {
+	return r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9' || strings.ContainsRune("-_./:=@%+,", r)
+}
+
+// shells lists the shells whose "-c" invocation marks the shell form of a
+// CMD or ENTRYPOINT.
+var shells = map[string]bool{
+	"/bin/sh":   true,
+	"sh":        true,
+	"/bin/bash": true,
+	"bash":      true,
+}
+
+// IsShellForm indicates whether a CMD or ENTRYPOINT is in shell form, that
+// is run through a shell, such as ["/bin/sh", "-c", "nginx"] as recorded
+// by the builder, or a single "/bin/sh -c nginx" element.
+func IsShellForm(args []string) bool {
+	if len(args) == 1 {
+		fields := strings.Fields(args[0])
+		return len(fields) >= 2 && shells[fields[0]] && fields[1] == "-c"
+	}
+	return len(args) >= 2 && shells[args[0]] && args[1] == "-c"
+}
+
+// UsesShellForm indicates whether the ENTRYPOINT or the CMD of the image
+// is in shell form.
+func (i ImageInspect) UsesShellForm() bool {
+	if i.Config == nil {
+		return false
+	}
+	return IsShellForm(i.Config.Entrypoint) || IsShellForm(i.Config.Cmd)
+}
@@ -0,0 +1,81 @@
+package types
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/hyperhq/hyper-api/types/container"
+	"github.com/hyperhq/hyper-api/types/strslice"
+)
+
+func TestEffectiveCmdLine(t *testing.T) {
+	cfg := &container.Config{
+		Entrypoint: strslice.StrSlice{"/docker-entrypoint.sh"},
+		Cmd:        strslice.StrSlice{"nginx", "-g", "daemon off;"},
+	}
+	testCases := []struct {
+		cfg      *container.Config
+		override []string
+		expected []string
+	}{
+		{cfg, nil, []string{"/docker-entrypoint.sh", "nginx", "-g", "daemon off;"}},
+		{cfg, []string{"nginx", "-t"}, []string{"/docker-entrypoint.sh", "nginx", "-t"}},
+		{&container.Config{Cmd: strslice.StrSlice{"sh"}}, nil, []string{"sh"}},
+		{nil, []string{"echo", "hi"}, []string{"echo", "hi"}},
+		{nil, nil, []string{}},
+	}
+	for _, c := range testCases {
+		actual := EffectiveCmdLine(c.cfg, c.override)
+		if !reflect.DeepEqual(actual, c.expected) {
+			t.Fatalf("expected %q, got %q", c.expected, actual)
+		}
+	}
+}
+
+func TestShellQuote(t *testing.T) {
+	testCases := []struct {
+		args     []string
+		expected string
+	}{
+		{[]string{"nginx", "-g", "daemon off;"}, `nginx -g 'daemon off;'`},
+		{[]string{"echo", "it's"}, `echo 'it'\''s'`},
+		{[]string{"sh", "-c", "echo $HOME > /tmp/out"}, `sh -c 'echo $HOME > /tmp/out'`},
+		{[]string{"env", "A=1", "--path=/usr/local/bin:/bin", "user@host"}, `env A=1 --path=/usr/local/bin:/bin user@host`},
+		{[]string{"printf", "", "*"}, `printf '' '*'`},
+		{nil, ""},
+	}
+	for _, c := range testCases {
+		if actual := ShellQuote(c.args); actual != c.expected {
+			t.Fatalf("expected %s, got %s", c.expected, actual)
+		}
+	}
+}
+
+func TestIsShellForm(t *testing.T) {
+	testCases := []struct {
+		args     []string
+		expected bool
+	}{
+		{[]string{"/bin/sh", "-c", "nginx -g 'daemon off;'"}, true},
+		{[]string{"/bin/sh -c nginx"}, true},
+		{[]string{"bash", "-c", "exec nginx"}, true},
+		{[]string{"nginx", "-g", "daemon off;"}, false},
+		{[]string{"/bin/sh"}, false},
+		{[]string{"/bin/sh", "/entrypoint.sh"}, false},
+		{nil, false},
+	}
+	for _, c := range testCases {
+		if actual := IsShellForm(c.args); actual != c.expected {
+			t.Fatalf("%q: expected %v, got %v", c.args, c.expected, actual)
+		}
+	}
+
+	image := ImageInspect{Config: &container.Config{Cmd: strslice.StrSlice{"/bin/sh", "-c", "node server.js"}}}
+	if !image.UsesShellForm() {
+		t.Fatal("expected the image to use the shell form")
+	}
+	image.Config = &container.Config{Entrypoint: strslice.StrSlice{"/docker-entrypoint.sh"}, Cmd: strslice.StrSlice{"nginx"}}
+	if image.UsesShellForm() || (ImageInspect{}).UsesShellForm() {
+		t.Fatal("expected the image to use the exec form")
+	}
+}
@@ -0,0 +1,31 @@
+// Package compose holds the types used to deploy multi-container
+// applications, or projects, through the compose endpoints.
+package compose
+
+// ComposeUpRequest is the request to create and start the services of a
+// project.
+type ComposeUpRequest struct {
+	Project       string
+	YAML          []byte // YAML is the raw compose file, parsed by the daemon
+	Detach        bool   // Detach returns once the services are started instead of streaming their output
+	ForceRecreate bool   // ForceRecreate recreates containers even if their configuration did not change
+}
+
+// ComposeDownRequest is the request to stop and remove the services of a
+// project.
+type ComposeDownRequest struct {
+	Project   string
+	RmVolumes bool // RmVolumes removes the volumes of the project
+}
+
+// ComposeProject describes a deployed compose project.
+type ComposeProject struct {
+	Name     string
+	Services []string
+	Status   string
+}
+
+// ComposeListResponse holds the compose projects of the user.
+type ComposeListResponse struct {
+	Projects []ComposeProject
+}
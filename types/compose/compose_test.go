@@ -0,0 +1,38 @@
+package compose
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+func TestComposeUpRequestJSON(t *testing.T) {
+	req := ComposeUpRequest{
+		Project:       "blog",
+		YAML:          []byte("web:\n  image: nginx\n"),
+		Detach:        true,
+		ForceRecreate: true,
+	}
+	b, err := json.Marshal(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var decoded ComposeUpRequest
+	if err := json.Unmarshal(b, &decoded); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(decoded, req) {
+		t.Fatalf("expected %+v, got %+v", req, decoded)
+	}
+}
+
+func TestComposeListResponse(t *testing.T) {
+	var list ComposeListResponse
+	if err := json.Unmarshal([]byte(`{"Projects":[{"Name":"blog","Services":["web","db"],"Status":"running"}]}`), &list); err != nil {
+		t.Fatal(err)
+	}
+	expected := []ComposeProject{{Name: "blog", Services: []string{"web", "db"}, Status: "running"}}
+	if !reflect.DeepEqual(list.Projects, expected) {
+		t.Fatalf("expected %+v, got %+v", expected, list.Projects)
+	}
+}
@@ -49,5 +49,45 @@ type ExecConfig struct {
 	AttachStdout bool     // Attach the standard error
 	Detach       bool     // Execute in detach mode
 	DetachKeys   string   // Escape keys for detach
+	Env          []string // Environment variables
 	Cmd          []string // Execution commands and args
 }
+
+// CheckConflicts looks for mutually exclusive settings in the create
+// config and returns a warning for each one it finds. It never fails, so
+// that callers can decide whether to surface the warnings or abort.
+func (cfg ContainerCreateConfig) CheckConflicts() []string {
+	var warnings []string
+	if cfg.HostConfig == nil {
+		return warnings
+	}
+	hc := cfg.HostConfig
+	publishes := len(hc.PortBindings) > 0 || hc.PublishAllPorts
+
+	switch {
+	case hc.NetworkMode.IsHost():
+		if publishes {
+			warnings = append(warnings, "published ports are discarded when using host network mode")
+		}
+		if len(hc.Links) > 0 {
+			warnings = append(warnings, "links are not supported when using host network mode")
+		}
+	case hc.NetworkMode.IsContainer():
+		if publishes {
+			warnings = append(warnings, "published ports are discarded when sharing another container's network")
+		}
+		if len(hc.DNS) > 0 || len(hc.ExtraHosts) > 0 {
+			warnings = append(warnings, "DNS and extra hosts are inherited when sharing another container's network")
+		}
+		if cfg.Config != nil && (cfg.Config.Hostname != "" || cfg.Config.MacAddress != "") {
+			warnings = append(warnings, "hostname and MAC address are inherited when sharing another container's network")
+		}
+	}
+	if cfg.Config != nil && cfg.Config.NetworkDisabled && publishes {
+		warnings = append(warnings, "published ports are discarded when networking is disabled")
+	}
+	if hc.AutoRemove && !hc.RestartPolicy.IsNone() {
+		warnings = append(warnings, "automatic removal conflicts with the restart policy "+hc.RestartPolicy.Name)
+	}
+	return warnings
+}
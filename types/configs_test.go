@@ -0,0 +1,34 @@
+package types
+
+import (
+	"testing"
+
+	"github.com/docker/go-connections/nat"
+	"github.com/hyperhq/hyper-api/types/container"
+)
+
+func TestCheckConflicts(t *testing.T) {
+	ports := nat.PortMap{"80/tcp": []nat.PortBinding{{HostPort: "8080"}}}
+	testCases := []struct {
+		cfg      ContainerCreateConfig
+		expected int
+	}{
+		{ContainerCreateConfig{}, 0},
+		{ContainerCreateConfig{HostConfig: &container.HostConfig{NetworkMode: "bridge", PortBindings: ports}}, 0},
+		{ContainerCreateConfig{HostConfig: &container.HostConfig{NetworkMode: "host", PortBindings: ports}}, 1},
+		{ContainerCreateConfig{HostConfig: &container.HostConfig{NetworkMode: "host", PublishAllPorts: true, Links: []string{"db:db"}}}, 2},
+		{
+			ContainerCreateConfig{
+				Config:     &container.Config{Hostname: "web"},
+				HostConfig: &container.HostConfig{NetworkMode: "container:db", DNS: []string{"8.8.8.8"}},
+			},
+			2,
+		},
+		{ContainerCreateConfig{HostConfig: &container.HostConfig{AutoRemove: true, RestartPolicy: container.RestartPolicy{Name: "always"}}}, 1},
+	}
+	for i, c := range testCases {
+		if warnings := c.cfg.CheckConflicts(); len(warnings) != c.expected {
+			t.Fatalf("case %d: expected %d warnings, got %v", i, c.expected, warnings)
+		}
+	}
+}
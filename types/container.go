@@ -0,0 +1,68 @@
+package types
+
+import (
+	"fmt"
+	"strings"
+)
+
+// ValidateContainerName checks that name is a valid container name: an
+// alphanumeric character followed by at least one alphanumeric, '_', '.'
+// or '-' character.
+func ValidateContainerName(name string) error {
+	if name == "" {
+		return fmt.Errorf("container name cannot be empty")
+	}
+	if !validNameRegexp.MatchString(name) {
+		return fmt.Errorf("invalid container name %q: only [a-zA-Z0-9][a-zA-Z0-9_.-] are allowed", name)
+	}
+	return nil
+}
+
+// CleanNames returns the names of the container without the leading slash
+// the daemon adds. Names of links to the container, which contain a
+// further slash (e.g. "/web/db"), are left out.
+func (c Container) CleanNames() []string {
+	names := make([]string, 0, len(c.Names))
+	for _, name := range c.Names {
+		name = strings.TrimPrefix(name, "/")
+		if strings.Contains(name, "/") {
+			continue
+		}
+		names = append(names, name)
+	}
+	return names
+}
+
+// ResolveContainer finds the container ref refers to, where ref is either
+// a full ID, an ID prefix, or one of the container names. An exact ID or
+// name wins over an ID prefix; an error is returned if nothing matches or
+// if the prefix matches more than one container.
+func ResolveContainer(cs []Container, ref string) (*Container, error) {
+	if ref == "" {
+		return nil, fmt.Errorf("no container reference given")
+	}
+
+	var matches []*Container
+	for i := range cs {
+		c := &cs[i]
+		if c.ID == ref {
+			return c, nil
+		}
+		for _, name := range c.CleanNames() {
+			if name == strings.TrimPrefix(ref, "/") {
+				return c, nil
+			}
+		}
+		if strings.HasPrefix(c.ID, ref) {
+			matches = append(matches, c)
+		}
+	}
+
+	switch len(matches) {
+	case 0:
+		return nil, fmt.Errorf("no such container: %s", ref)
+	case 1:
+		return matches[0], nil
+	}
+	return nil, fmt.Errorf("container reference %s is ambiguous: it matches %d containers", ref, len(matches))
+}
@@ -0,0 +1,148 @@
+package container
+
+import (
+	"github.com/docker/go-connections/nat"
+	"github.com/docker/go-units"
+	"github.com/hyperhq/hyper-api/types/blkiodev"
+	"github.com/hyperhq/hyper-api/types/strslice"
+)
+
+// Clone returns a deep copy of the config.
+func (c *Config) Clone() *Config {
+	if c == nil {
+		return nil
+	}
+	clone := *c
+	if c.ExposedPorts != nil {
+		clone.ExposedPorts = make(map[nat.Port]struct{}, len(c.ExposedPorts))
+		for p := range c.ExposedPorts {
+			clone.ExposedPorts[p] = struct{}{}
+		}
+	}
+	clone.Env = copyStrings(c.Env)
+	clone.Cmd = strslice.StrSlice(copyStrings(c.Cmd))
+	if c.Healthcheck != nil {
+		healthcheck := *c.Healthcheck
+		healthcheck.Test = copyStrings(c.Healthcheck.Test)
+		clone.Healthcheck = &healthcheck
+	}
+	if c.Volumes != nil {
+		clone.Volumes = make(map[string]struct{}, len(c.Volumes))
+		for v := range c.Volumes {
+			clone.Volumes[v] = struct{}{}
+		}
+	}
+	clone.Entrypoint = strslice.StrSlice(copyStrings(c.Entrypoint))
+	clone.OnBuild = copyStrings(c.OnBuild)
+	clone.Labels = copyStringMap(c.Labels)
+	return &clone
+}
+
+// Clone returns a deep copy of the host config.
+func (hc *HostConfig) Clone() *HostConfig {
+	if hc == nil {
+		return nil
+	}
+	clone := *hc
+	clone.Binds = copyStrings(hc.Binds)
+	clone.LogConfig.Config = copyStringMap(hc.LogConfig.Config)
+	if hc.PortBindings != nil {
+		clone.PortBindings = make(nat.PortMap, len(hc.PortBindings))
+		for p, bindings := range hc.PortBindings {
+			if bindings != nil {
+				bindings = append([]nat.PortBinding(nil), bindings...)
+			}
+			clone.PortBindings[p] = bindings
+		}
+	}
+	clone.VolumesFrom = copyStrings(hc.VolumesFrom)
+	clone.CapAdd = strslice.StrSlice(copyStrings(hc.CapAdd))
+	clone.CapDrop = strslice.StrSlice(copyStrings(hc.CapDrop))
+	clone.DNS = copyStrings(hc.DNS)
+	clone.DNSOptions = copyStrings(hc.DNSOptions)
+	clone.DNSSearch = copyStrings(hc.DNSSearch)
+	clone.ExtraHosts = copyStrings(hc.ExtraHosts)
+	clone.GroupAdd = copyStrings(hc.GroupAdd)
+	clone.Links = copyStrings(hc.Links)
+	clone.SecurityOpt = copyStrings(hc.SecurityOpt)
+	clone.StorageOpt = copyStringMap(hc.StorageOpt)
+	clone.Tmpfs = copyStringMap(hc.Tmpfs)
+	clone.Sysctls = copyStringMap(hc.Sysctls)
+	if hc.Init != nil {
+		init := *hc.Init
+		clone.Init = &init
+	}
+	clone.Resources = hc.Resources.clone()
+	return &clone
+}
+
+// clone returns a deep copy of the resources.
+func (r Resources) clone() Resources {
+	clone := r
+	if r.BlkioWeightDevice != nil {
+		clone.BlkioWeightDevice = make([]*blkiodev.WeightDevice, len(r.BlkioWeightDevice))
+		for i, d := range r.BlkioWeightDevice {
+			if d != nil {
+				dev := *d
+				clone.BlkioWeightDevice[i] = &dev
+			}
+		}
+	}
+	clone.BlkioDeviceReadBps = copyThrottleDevices(r.BlkioDeviceReadBps)
+	clone.BlkioDeviceWriteBps = copyThrottleDevices(r.BlkioDeviceWriteBps)
+	clone.BlkioDeviceReadIOps = copyThrottleDevices(r.BlkioDeviceReadIOps)
+	clone.BlkioDeviceWriteIOps = copyThrottleDevices(r.BlkioDeviceWriteIOps)
+	if r.Devices != nil {
+		clone.Devices = append([]DeviceMapping(nil), r.Devices...)
+	}
+	if r.MemorySwappiness != nil {
+		swappiness := *r.MemorySwappiness
+		clone.MemorySwappiness = &swappiness
+	}
+	if r.OomKillDisable != nil {
+		disable := *r.OomKillDisable
+		clone.OomKillDisable = &disable
+	}
+	if r.Ulimits != nil {
+		clone.Ulimits = make([]*units.Ulimit, len(r.Ulimits))
+		for i, u := range r.Ulimits {
+			if u != nil {
+				ulimit := *u
+				clone.Ulimits[i] = &ulimit
+			}
+		}
+	}
+	return clone
+}
+
+func copyThrottleDevices(devices []*blkiodev.ThrottleDevice) []*blkiodev.ThrottleDevice {
+	if devices == nil {
+		return nil
+	}
+	clone := make([]*blkiodev.ThrottleDevice, len(devices))
+	for i, d := range devices {
+		if d != nil {
+			dev := *d
+			clone[i] = &dev
+		}
+	}
+	return clone
+}
+
+func copyStrings(s []string) []string {
+	if s == nil {
+		return nil
+	}
+	return append([]string(nil), s...)
+}
+
+func copyStringMap(m map[string]string) map[string]string {
+	if m == nil {
+		return nil
+	}
+	clone := make(map[string]string, len(m))
+	for k, v := range m {
+		clone[k] = v
+	}
+	return clone
+}
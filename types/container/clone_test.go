@@ -0,0 +1,37 @@
+package container
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/hyperhq/hyper-api/types/blkiodev"
+)
+
+func TestHostConfigClone(t *testing.T) {
+	init := true
+	original := &HostConfig{
+		DNS:  []string{"8.8.8.8"},
+		Init: &init,
+		Resources: Resources{
+			BlkioWeightDevice:  []*blkiodev.WeightDevice{{Path: "/dev/sda", Weight: 500}},
+			BlkioDeviceReadBps: []*blkiodev.ThrottleDevice{{Path: "/dev/sda", Rate: 1024}},
+			Devices:            []DeviceMapping{{PathOnHost: "/dev/fuse"}},
+		},
+	}
+	clone := original.Clone()
+	if !reflect.DeepEqual(original, clone) {
+		t.Fatal("expected the clone to equal the original")
+	}
+	clone.DNS[0] = "1.1.1.1"
+	*clone.Init = false
+	clone.BlkioWeightDevice[0].Weight = 10
+	clone.BlkioDeviceReadBps[0].Rate = 1
+	clone.Devices[0].PathOnHost = "/dev/null"
+	if original.DNS[0] != "8.8.8.8" || !*original.Init || original.BlkioWeightDevice[0].Weight != 500 ||
+		original.BlkioDeviceReadBps[0].Rate != 1024 || original.Devices[0].PathOnHost != "/dev/fuse" {
+		t.Fatalf("expected the original to be left untouched, got %+v", original)
+	}
+	if (*HostConfig)(nil).Clone() != nil || (*Config)(nil).Clone() != nil {
+		t.Fatal("expected nil clones")
+	}
+}
@@ -0,0 +1,83 @@
+package container
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// dnsLabelRegexp matches a single label of a DNS name.
+var dnsLabelRegexp = regexp.MustCompile(`^[a-zA-Z0-9]([a-zA-Z0-9-]*[a-zA-Z0-9])?$`)
+
+// resolvOptions lists the resolv.conf options accepted by ValidateDNSOptions.
+// Options that take a value map to true.
+var resolvOptions = map[string]bool{
+	"ndots":                 true,
+	"timeout":               true,
+	"attempts":              true,
+	"rotate":                false,
+	"debug":                 false,
+	"no-check-names":        false,
+	"inet6":                 false,
+	"edns0":                 false,
+	"single-request":        false,
+	"single-request-reopen": false,
+	"no-tld-query":          false,
+	"use-vc":                false,
+}
+
+// ValidateDNSSearch checks that each entry of domains, as set in
+// HostConfig.DNSSearch, is a valid DNS name. A single "." is accepted and
+// means no search domain.
+func ValidateDNSSearch(domains []string) error {
+	for _, d := range domains {
+		if d == "." {
+			continue
+		}
+		if err := validateDomain(d); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func validateDomain(domain string) error {
+	name := strings.TrimSuffix(domain, ".")
+	if name == "" || len(name) > 253 {
+		return fmt.Errorf("invalid DNS search domain: %q", domain)
+	}
+	for _, label := range strings.Split(name, ".") {
+		if len(label) > 63 || !dnsLabelRegexp.MatchString(label) {
+			return fmt.Errorf("invalid DNS search domain: %q", domain)
+		}
+	}
+	return nil
+}
+
+// ValidateDNSOptions checks that each entry of opts, as set in
+// HostConfig.DNSOptions, is a known resolv.conf option such as "rotate" or
+// "ndots:2", with a valid value where one is expected.
+func ValidateDNSOptions(opts []string) error {
+	for _, opt := range opts {
+		parts := strings.SplitN(opt, ":", 2)
+		takesValue, ok := resolvOptions[parts[0]]
+		if !ok {
+			return fmt.Errorf("invalid DNS option: %q", opt)
+		}
+		if !takesValue {
+			if len(parts) > 1 {
+				return fmt.Errorf("invalid DNS option: %q does not take a value", opt)
+			}
+			continue
+		}
+		if len(parts) < 2 {
+			return fmt.Errorf("invalid DNS option: %q requires a value", opt)
+		}
+		n, err := strconv.Atoi(parts[1])
+		if err != nil || n < 0 || (n == 0 && parts[0] != "ndots") {
+			return fmt.Errorf("invalid DNS option: %q has an invalid value", opt)
+		}
+	}
+	return nil
+}
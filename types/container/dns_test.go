@@ -0,0 +1,27 @@
+package container
+
+import "testing"
+
+func TestValidateDNSSearch(t *testing.T) {
+	valid := []string{"example.com", "svc.cluster.local.", "a-b.example", "."}
+	if err := ValidateDNSSearch(valid); err != nil {
+		t.Fatalf("expected %v to be valid, got %v", valid, err)
+	}
+	for _, d := range []string{"", "-bad.com", "bad-.com", "under_score.com", "a..b"} {
+		if err := ValidateDNSSearch([]string{d}); err == nil {
+			t.Fatalf("expected an error for %q", d)
+		}
+	}
+}
+
+func TestValidateDNSOptions(t *testing.T) {
+	valid := []string{"rotate", "ndots:2", "ndots:0", "timeout:1", "attempts:3", "edns0"}
+	if err := ValidateDNSOptions(valid); err != nil {
+		t.Fatalf("expected %v to be valid, got %v", valid, err)
+	}
+	for _, opt := range []string{"ndots:x", "ndots:-1", "ndots", "timeout:0", "rotate:1", "fast"} {
+		if err := ValidateDNSOptions([]string{opt}); err == nil {
+			t.Fatalf("expected an error for %q", opt)
+		}
+	}
+}
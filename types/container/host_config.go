@@ -233,6 +233,7 @@ type Resources struct {
 	// Applicable to all platforms
 	CPUShares int64 `json:"CpuShares"` // CPU shares (relative weight vs. other containers)
 	Memory    int64 // Memory limit (in bytes)
+	NanoCPUs  int64 `json:"NanoCpus"` // CPU quota in units of 10^-9 CPUs
 
 	// Applicable to UNIX platforms
 	CgroupParent         string // Parent cgroup.
@@ -309,6 +310,7 @@ type HostConfig struct {
 	UsernsMode      UsernsMode        // The user namespace to use for the container
 	ShmSize         int64             // Total shm memory usage
 	Sysctls         map[string]string `json:",omitempty"` // List of Namespaced sysctls used for the container
+	Init            *bool             `json:",omitempty"` // Run an init process inside the container, if nil use the daemon's default
 
 	// Applicable to Windows
 	ConsoleSize [2]int    // Initial console size
@@ -0,0 +1,40 @@
+package container
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/docker/go-units"
+)
+
+// Log rotation options of the json-file logging driver.
+const (
+	LogOptMaxSize = "max-size"
+	LogOptMaxFile = "max-file"
+)
+
+// ValidateLogRotation validates the rotation options of LogConfig.Config:
+// "max-size" must be a byte quantity such as "10m" and "max-file" a
+// positive number of files. Both are optional and other options are
+// ignored.
+func ValidateLogRotation(opts map[string]string) error {
+	if v, ok := opts[LogOptMaxSize]; ok {
+		size, err := units.RAMInBytes(v)
+		if err != nil {
+			return fmt.Errorf("invalid log option %s %q: %v", LogOptMaxSize, v, err)
+		}
+		if size <= 0 {
+			return fmt.Errorf("invalid log option %s %q: must be positive", LogOptMaxSize, v)
+		}
+	}
+	if v, ok := opts[LogOptMaxFile]; ok {
+		n, err := strconv.Atoi(v)
+		if err != nil {
+			return fmt.Errorf("invalid log option %s %q: %v", LogOptMaxFile, v, err)
+		}
+		if n <= 0 {
+			return fmt.Errorf("invalid log option %s %q: must be positive", LogOptMaxFile, v)
+		}
+	}
+	return nil
+}
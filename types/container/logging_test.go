@@ -0,0 +1,29 @@
+package container
+
+import "testing"
+
+func TestValidateLogRotation(t *testing.T) {
+	testCases := []struct {
+		opts          map[string]string
+		expectedError bool
+	}{
+		{opts: nil},
+		{opts: map[string]string{"max-size": "10m", "max-file": "3"}},
+		{opts: map[string]string{"max-size": "1g", "labels": "app"}},
+		{opts: map[string]string{"max-file": "1"}},
+		{opts: map[string]string{"max-file": "0"}, expectedError: true},
+		{opts: map[string]string{"max-file": "-2"}, expectedError: true},
+		{opts: map[string]string{"max-file": "three"}, expectedError: true},
+		{opts: map[string]string{"max-size": "big"}, expectedError: true},
+		{opts: map[string]string{"max-size": "0"}, expectedError: true},
+	}
+	for _, c := range testCases {
+		err := ValidateLogRotation(c.opts)
+		if err != nil && !c.expectedError {
+			t.Fatalf("%v: unexpected error: %v", c.opts, err)
+		}
+		if err == nil && c.expectedError {
+			t.Fatalf("%v: expected an error", c.opts)
+		}
+	}
+}
@@ -0,0 +1,52 @@
+package container
+
+import "fmt"
+
+// restartPolicies lists the restart policy names accepted by the daemon.
+var restartPolicies = map[string]bool{
+	"no":             true,
+	"always":         true,
+	"unless-stopped": true,
+	"on-failure":     true,
+}
+
+// IsValidRestartPolicy indicates whether name is a known restart policy.
+// An empty name is the same as "no", see RestartPolicy.IsNone.
+func IsValidRestartPolicy(name string) bool {
+	return name == "" || restartPolicies[name]
+}
+
+// ValidateRestartPolicy checks that name is a known restart policy and
+// that only the "on-failure" policy carries a maximum retry count.
+func ValidateRestartPolicy(name string, maxRetry int) error {
+	if !IsValidRestartPolicy(name) {
+		return fmt.Errorf("invalid restart policy %q: must be one of no, always, unless-stopped or on-failure", name)
+	}
+	if maxRetry < 0 {
+		return fmt.Errorf("invalid maximum retry count %d: must not be negative", maxRetry)
+	}
+	if maxRetry != 0 && name != "on-failure" {
+		return fmt.Errorf("maximum retry count cannot be used with restart policy %q", name)
+	}
+	return nil
+}
+
+// RestartPolicyChangeWarning returns a warning when updating the restart
+// policy of a container from old to updated makes it restart less often:
+// when it stops restarting at all, when it stops restarting after a
+// successful exit, or when its maximum retry count is lowered. It returns
+// an empty string otherwise.
+func RestartPolicyChangeWarning(old, updated RestartPolicy) string {
+	switch {
+	case old.IsNone():
+		return ""
+	case updated.IsNone():
+		return fmt.Sprintf("changing the restart policy from %q to %q: the container will no longer be restarted when it exits", old.Name, "no")
+	case !old.IsOnFailure() && updated.IsOnFailure():
+		return fmt.Sprintf("changing the restart policy from %q to %q: the container will no longer be restarted when it exits successfully", old.Name, updated.Name)
+	case old.IsOnFailure() && updated.IsOnFailure() && updated.MaximumRetryCount > 0 &&
+		(old.MaximumRetryCount == 0 || updated.MaximumRetryCount < old.MaximumRetryCount):
+		return fmt.Sprintf("lowering the maximum retry count of the restart policy to %d: the container may not be restarted after repeated failures", updated.MaximumRetryCount)
+	}
+	return ""
+}
@@ -0,0 +1,58 @@
+package container
+
+import "testing"
+
+func TestValidateRestartPolicy(t *testing.T) {
+	testCases := []struct {
+		name          string
+		maxRetry      int
+		expectedError bool
+	}{
+		{name: ""},
+		{name: "no"},
+		{name: "always"},
+		{name: "unless-stopped"},
+		{name: "on-failure"},
+		{name: "on-failure", maxRetry: 5},
+		{name: "always", maxRetry: 3, expectedError: true},
+		{name: "no", maxRetry: 1, expectedError: true},
+		{name: "on-failure", maxRetry: -1, expectedError: true},
+		{name: "sometimes", expectedError: true},
+		{name: "Always", expectedError: true},
+	}
+	for _, c := range testCases {
+		err := ValidateRestartPolicy(c.name, c.maxRetry)
+		if err != nil && !c.expectedError {
+			t.Fatalf("%q, %d: unexpected error: %v", c.name, c.maxRetry, err)
+		}
+		if err == nil && c.expectedError {
+			t.Fatalf("%q, %d: expected an error", c.name, c.maxRetry)
+		}
+	}
+}
+
+func TestRestartPolicyChangeWarning(t *testing.T) {
+	onFailure := func(n int) RestartPolicy { return RestartPolicy{Name: "on-failure", MaximumRetryCount: n} }
+	testCases := []struct {
+		old, updated RestartPolicy
+		warn         bool
+	}{
+		{RestartPolicy{Name: "always"}, RestartPolicy{Name: "no"}, true},
+		{RestartPolicy{Name: "unless-stopped"}, RestartPolicy{}, true},
+		{onFailure(3), RestartPolicy{Name: "no"}, true},
+		{RestartPolicy{Name: "always"}, onFailure(0), true},
+		{onFailure(5), onFailure(2), true},
+		{onFailure(0), onFailure(10), true},
+		{RestartPolicy{Name: "no"}, RestartPolicy{Name: "always"}, false},
+		{RestartPolicy{}, RestartPolicy{Name: "no"}, false},
+		{RestartPolicy{Name: "always"}, RestartPolicy{Name: "unless-stopped"}, false},
+		{onFailure(2), onFailure(5), false},
+		{onFailure(2), onFailure(0), false},
+		{onFailure(2), RestartPolicy{Name: "always"}, false},
+	}
+	for _, c := range testCases {
+		if warning := RestartPolicyChangeWarning(c.old, c.updated); (warning != "") != c.warn {
+			t.Fatalf("%+v -> %+v: expected a warning: %v, got %q", c.old, c.updated, c.warn, warning)
+		}
+	}
+}
@@ -0,0 +1,37 @@
+package container
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/docker/go-units"
+)
+
+// StorageOptSize is the HostConfig.StorageOpt key setting the size of the
+// container root filesystem.
+const StorageOptSize = "size"
+
+// ParseStorageOpts validates the storage options of a HostConfig. Only the
+// "size" option is supported and it must be a byte quantity such as
+// "10G" or "1073741824".
+func ParseStorageOpts(opts map[string]string) error {
+	for k, v := range opts {
+		if k != StorageOptSize {
+			return fmt.Errorf("unknown storage option: %q", k)
+		}
+		size, err := units.RAMInBytes(v)
+		if err != nil {
+			return fmt.Errorf("invalid storage option size %q: %v", v, err)
+		}
+		if size <= 0 {
+			return fmt.Errorf("invalid storage option size %q: must be positive", v)
+		}
+	}
+	return nil
+}
+
+// SetRootfsSize sets the size of the container root filesystem in the
+// storage options, which must not be nil.
+func SetRootfsSize(opts map[string]string, bytes int64) {
+	opts[StorageOptSize] = strconv.FormatInt(bytes, 10)
+}
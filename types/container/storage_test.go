@@ -0,0 +1,37 @@
+package container
+
+import "testing"
+
+func TestSetRootfsSize(t *testing.T) {
+	opts := map[string]string{}
+	SetRootfsSize(opts, 20*1024*1024*1024)
+	if opts["size"] != "21474836480" {
+		t.Fatalf("expected size 21474836480, got %q", opts["size"])
+	}
+	if err := ParseStorageOpts(opts); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestParseStorageOpts(t *testing.T) {
+	testCases := []struct {
+		opts          map[string]string
+		expectedError bool
+	}{
+		{nil, false},
+		{map[string]string{"size": "10G"}, false},
+		{map[string]string{"size": "512m"}, false},
+		{map[string]string{"size": "big"}, true},
+		{map[string]string{"size": "0"}, true},
+		{map[string]string{"dm.basesize": "10G"}, true},
+	}
+	for _, c := range testCases {
+		err := ParseStorageOpts(c.opts)
+		if err != nil && !c.expectedError {
+			t.Fatalf("%v: unexpected error: %v", c.opts, err)
+		}
+		if err == nil && c.expectedError {
+			t.Fatalf("%v: expected an error", c.opts)
+		}
+	}
+}
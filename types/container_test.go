@@ -0,0 +1,83 @@
+package types
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestCleanNames(t *testing.T) {
+	c := Container{Names: []string{"/db", "/web/db"}}
+	if names := c.CleanNames(); !reflect.DeepEqual(names, []string{"db"}) {
+		t.Fatalf("expected [db], got %v", names)
+	}
+}
+
+func TestResolveContainer(t *testing.T) {
+	cs := []Container{
+		{ID: "4fa6e0f0c6786287e131c3852c58a2e01cc697a68231826813597e4994f1d6e2", Names: []string{"/web"}},
+		{ID: "4fb7a8d2ba3c3c9c4e3ff63e4f2e5d8b1f7c1f0d8f54a0e0c1e9b6a6f2a3d4c5", Names: []string{"/db"}},
+		{ID: "9a0b1c2d3e4f5a6b7c8d9e0f1a2b3c4d5e6f7a8b9c0d1e2f3a4b5c6d7e8f9a0b", Names: []string{"/4fa"}},
+	}
+	testCases := []struct {
+		ref           string
+		expectedID    string
+		expectedError bool
+	}{
+		{ref: "web", expectedID: cs[0].ID},
+		{ref: "/db", expectedID: cs[1].ID},
+		{ref: "4fb7a8", expectedID: cs[1].ID},
+		{ref: cs[2].ID, expectedID: cs[2].ID},
+		// names win over ID prefixes
+		{ref: "4fa", expectedID: cs[2].ID},
+		{ref: "4f", expectedError: true},
+		{ref: "cache", expectedError: true},
+		{ref: "", expectedError: true},
+	}
+	for _, c := range testCases {
+		container, err := ResolveContainer(cs, c.ref)
+		if c.expectedError {
+			if err == nil {
+				t.Fatalf("%q: expected an error, got %s", c.ref, container.ID)
+			}
+			continue
+		}
+		if err != nil {
+			t.Fatalf("%q: unexpected error: %v", c.ref, err)
+		}
+		if container.ID != c.expectedID {
+			t.Fatalf("%q: expected %s, got %s", c.ref, c.expectedID, container.ID)
+		}
+	}
+}
+
+func TestValidateContainerName(t *testing.T) {
+	testCases := []struct {
+		name  string
+		valid bool
+	}{
+		{"web", true},
+		{"web-1.blue_green", true},
+		{"0db", true},
+		{"", false},
+		{"a", false},
+		{".web", false},
+		{"_web", false},
+		{"-web", false},
+		{"/web", false},
+		{"web/db", false},
+		{"wéb", false},
+		{"数据库", false},
+		{"web db", false},
+	}
+	for _, c := range testCases {
+		if err := ValidateContainerName(c.name); (err == nil) != c.valid {
+			t.Fatalf("%q: expected valid=%v, got %v", c.name, c.valid, err)
+		}
+	}
+	if _, err := (ContainerRenameOptions{Name: ".web"}).ToQuery(); err == nil {
+		t.Fatal("expected an error for an invalid name")
+	}
+	if query, err := (ContainerRenameOptions{Name: "web2"}).ToQuery(); err != nil || query.Get("name") != "web2" {
+		t.Fatalf("unexpected query %v, %v", query, err)
+	}
+}
@@ -0,0 +1,51 @@
+package types
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/url"
+)
+
+// PathStatHeader is the response header carrying the base64 encoded
+// ContainerPathStat of the path targeted by the archive endpoints.
+const PathStatHeader = "X-Docker-Container-Path-Stat"
+
+// EncodePathStat encodes stat as the value of the PathStatHeader header.
+// Mtime is encoded in the RFC3339Nano format.
+func EncodePathStat(stat ContainerPathStat) (string, error) {
+	b, err := json.Marshal(stat)
+	if err != nil {
+		return "", err
+	}
+	return base64.StdEncoding.EncodeToString(b), nil
+}
+
+// DecodePathStat decodes the value of the PathStatHeader header.
+func DecodePathStat(header string) (ContainerPathStat, error) {
+	var stat ContainerPathStat
+	b, err := base64.StdEncoding.DecodeString(header)
+	if err != nil {
+		return stat, fmt.Errorf("unable to decode container path stat header: %v", err)
+	}
+	if err := json.Unmarshal(b, &stat); err != nil {
+		return stat, fmt.Errorf("unable to decode container path stat header: %v", err)
+	}
+	return stat, nil
+}
+
+// ToQuery encodes the options as the query parameters of the archive
+// upload endpoint. The destination path in the container is set by the
+// caller.
+func (o CopyToContainerOptions) ToQuery() url.Values {
+	query := url.Values{}
+	// Do not allow for an existing directory to be overwritten by a
+	// non-directory and vice versa.
+	if !o.AllowOverwriteDirWithFile {
+		query.Set("noOverwriteDirNonDir", "true")
+	}
+	if o.CopyUIDGID {
+		query.Set("copyUIDGID", "true")
+	}
+	return query
+}
@@ -0,0 +1,59 @@
+package types
+
+import (
+	"encoding/base64"
+	"os"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestPathStatRoundTrip(t *testing.T) {
+	stat := ContainerPathStat{
+		Name:       "nginx.conf",
+		Size:       643,
+		Mode:       os.ModeSymlink | 0777,
+		Mtime:      time.Date(2017, 1, 21, 11, 32, 7, 123456789, time.FixedZone("CET", 3600)),
+		LinkTarget: "/etc/nginx/nginx.conf.default",
+	}
+	header, err := EncodePathStat(stat)
+	if err != nil {
+		t.Fatal(err)
+	}
+	raw, err := base64.StdEncoding.DecodeString(header)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(raw), `"mtime":"2017-01-21T11:32:07.123456789+01:00"`) {
+		t.Fatalf("expected an RFC3339Nano mtime, got %s", raw)
+	}
+
+	decoded, err := DecodePathStat(header)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !decoded.Mtime.Equal(stat.Mtime) {
+		t.Fatalf("expected mtime %v, got %v", stat.Mtime, decoded.Mtime)
+	}
+	decoded.Mtime = stat.Mtime
+	if decoded != stat {
+		t.Fatalf("expected %+v, got %+v", stat, decoded)
+	}
+
+	for _, header := range []string{"not base64!", base64.StdEncoding.EncodeToString([]byte("{"))} {
+		if _, err := DecodePathStat(header); err == nil {
+			t.Fatalf("expected an error for header %q", header)
+		}
+	}
+}
+
+func TestCopyToContainerOptionsToQuery(t *testing.T) {
+	query := CopyToContainerOptions{}.ToQuery()
+	if len(query) != 1 || query.Get("noOverwriteDirNonDir") != "true" {
+		t.Fatalf("unexpected query: %v", query)
+	}
+	query = CopyToContainerOptions{AllowOverwriteDirWithFile: true, CopyUIDGID: true}.ToQuery()
+	if len(query) != 1 || query.Get("copyUIDGID") != "true" {
+		t.Fatalf("unexpected query: %v", query)
+	}
+}
@@ -0,0 +1,43 @@
+package types
+
+import (
+	"fmt"
+	"time"
+)
+
+// unixTime converts a Unix timestamp in seconds to a UTC time. A zero
+// timestamp converts to the zero time.
+func unixTime(sec int64) time.Time {
+	if sec == 0 {
+		return time.Time{}
+	}
+	return time.Unix(sec, 0).UTC()
+}
+
+// CreatedTime returns the creation time of the container.
+func (c Container) CreatedTime() time.Time {
+	return unixTime(c.Created)
+}
+
+// CreatedTime returns the creation time of the image.
+func (i Image) CreatedTime() time.Time {
+	return unixTime(i.Created)
+}
+
+// CreatedTime returns the creation time of the history entry.
+func (h ImageHistory) CreatedTime() time.Time {
+	return unixTime(h.Created)
+}
+
+// CreatedTime parses the creation time of the image, which the daemon
+// reports in RFC3339Nano format. An empty value parses to the zero time.
+func (i ImageInspect) CreatedTime() (time.Time, error) {
+	if i.Created == "" {
+		return time.Time{}, nil
+	}
+	t, err := time.Parse(time.RFC3339Nano, i.Created)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid image creation time %q: %v", i.Created, err)
+	}
+	return t, nil
+}
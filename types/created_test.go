@@ -0,0 +1,36 @@
+package types
+
+import (
+	"testing"
+	"time"
+)
+
+func TestCreatedTime(t *testing.T) {
+	expected := time.Date(2017, time.January, 2, 15, 4, 5, 0, time.UTC)
+	if created := (Container{Created: expected.Unix()}).CreatedTime(); !created.Equal(expected) || created.Location() != time.UTC {
+		t.Fatalf("expected %v, got %v", expected, created)
+	}
+	if created := (Image{Created: expected.Unix()}).CreatedTime(); !created.Equal(expected) {
+		t.Fatalf("expected %v, got %v", expected, created)
+	}
+	if created := (ImageHistory{Created: expected.Unix()}).CreatedTime(); !created.Equal(expected) {
+		t.Fatalf("expected %v, got %v", expected, created)
+	}
+	if created := (Container{}).CreatedTime(); !created.IsZero() {
+		t.Fatalf("expected the zero time, got %v", created)
+	}
+}
+
+func TestImageInspectCreatedTime(t *testing.T) {
+	created, err := ImageInspect{Created: "2017-01-02T15:04:05.123456789Z"}.CreatedTime()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if expected := time.Date(2017, time.January, 2, 15, 4, 5, 123456789, time.UTC); !created.Equal(expected) {
+		t.Fatalf("expected %v, got %v", expected, created)
+	}
+
+	if _, err := (ImageInspect{Created: "last tuesday"}).CreatedTime(); err == nil {
+		t.Fatal("expected an error for a malformed creation time")
+	}
+}
@@ -0,0 +1,68 @@
+package types
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/hyperhq/hyper-api/types/registry"
+)
+
+// CredentialStore is implemented by the backends that keep registry
+// credentials, such as an in-memory map or a native keychain. Registries
+// are identified by their address, normalized with
+// registry.NormalizeRegistryAddress.
+type CredentialStore interface {
+	// Get returns the credentials stored for the registry.
+	Get(registry string) (AuthConfig, error)
+	// Store saves the credentials for the registry in auth.ServerAddress.
+	Store(auth AuthConfig) error
+	// Erase removes the credentials stored for the registry.
+	Erase(registry string) error
+}
+
+type memoryCredentialStore struct {
+	mu    sync.Mutex
+	auths map[string]AuthConfig
+}
+
+// NewMemoryCredentialStore returns a CredentialStore that keeps the
+// credentials in memory. It is safe for concurrent use.
+func NewMemoryCredentialStore() CredentialStore {
+	return &memoryCredentialStore{auths: make(map[string]AuthConfig)}
+}
+
+func (s *memoryCredentialStore) Get(addr string) (AuthConfig, error) {
+	key, err := registry.NormalizeRegistryAddress(addr)
+	if err != nil {
+		return AuthConfig{}, err
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	auth, ok := s.auths[key]
+	if !ok {
+		return AuthConfig{}, fmt.Errorf("no credentials stored for %s", key)
+	}
+	return auth, nil
+}
+
+func (s *memoryCredentialStore) Store(auth AuthConfig) error {
+	key, err := registry.NormalizeRegistryAddress(auth.ServerAddress)
+	if err != nil {
+		return err
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.auths[key] = auth
+	return nil
+}
+
+func (s *memoryCredentialStore) Erase(addr string) error {
+	key, err := registry.NormalizeRegistryAddress(addr)
+	if err != nil {
+		return err
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.auths, key)
+	return nil
+}
@@ -0,0 +1,34 @@
+package types
+
+import "testing"
+
+func TestMemoryCredentialStore(t *testing.T) {
+	store := NewMemoryCredentialStore()
+	auth := AuthConfig{
+		Username:      "user",
+		Password:      "secret",
+		ServerAddress: "https://registry.hyper.sh/v1/",
+	}
+	if err := store.Store(auth); err != nil {
+		t.Fatal(err)
+	}
+
+	actual, err := store.Get("registry.hyper.sh")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if actual != auth {
+		t.Fatalf("expected %+v, got %+v", auth, actual)
+	}
+
+	if _, err := store.Get("index.docker.io"); err == nil {
+		t.Fatal("expected an error for a registry without credentials")
+	}
+
+	if err := store.Erase("https://registry.hyper.sh"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := store.Get("registry.hyper.sh"); err == nil {
+		t.Fatal("expected the credentials to be erased")
+	}
+}
@@ -0,0 +1,149 @@
+package types
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/hyperhq/hyper-api/types/container"
+	"github.com/hyperhq/hyper-api/types/strslice"
+)
+
+// CronCreateRequest holds the parameters of a cron job running a container
+// from Image on Schedule.
+type CronCreateRequest struct {
+	Name          string
+	Schedule      string
+	Image         string
+	Command       []string
+	Mail          string // Mail is the address notified of the runs of the job
+	ContainerName string
+}
+
+// Cron returns the job to send to CronCreate, after checking the request.
+func (r CronCreateRequest) Cron() (Cron, error) {
+	if r.Name == "" {
+		return Cron{}, fmt.Errorf("cron name cannot be empty")
+	}
+	if r.Image == "" {
+		return Cron{}, fmt.Errorf("cron %s: an image is required", r.Name)
+	}
+	if err := ValidateCronSchedule(r.Schedule); err != nil {
+		return Cron{}, fmt.Errorf("cron %s: %v", r.Name, err)
+	}
+	return Cron{
+		Name:          r.Name,
+		Schedule:      r.Schedule,
+		ContainerName: r.ContainerName,
+		Config: &container.Config{
+			Image: r.Image,
+			Cmd:   strslice.StrSlice(r.Command),
+		},
+		HostConfig: &container.HostConfig{},
+		OwnerEmail: r.Mail,
+	}, nil
+}
+
+// CronListResponse holds the cron jobs returned by the /crons endpoint. It
+// is encoded as a bare JSON array.
+type CronListResponse struct {
+	Crons []Cron
+}
+
+// MarshalJSON implements json.Marshaler.
+func (r CronListResponse) MarshalJSON() ([]byte, error) {
+	if r.Crons == nil {
+		return []byte("[]"), nil
+	}
+	return json.Marshal(r.Crons)
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (r *CronListResponse) UnmarshalJSON(data []byte) error {
+	return json.Unmarshal(data, &r.Crons)
+}
+
+// cronShortcuts lists the predefined schedules accepted in place of a cron
+// expression.
+var cronShortcuts = map[string]bool{
+	"@yearly":   true,
+	"@annually": true,
+	"@monthly":  true,
+	"@weekly":   true,
+	"@daily":    true,
+	"@midnight": true,
+	"@hourly":   true,
+}
+
+// cronFields holds the name and the bounds of the fields of a cron
+// expression. Both 0 and 7 stand for Sunday in the day of week field.
+var cronFields = []struct {
+	name     string
+	min, max int
+}{
+	{"minute", 0, 59},
+	{"hour", 0, 23},
+	{"day of month", 1, 31},
+	{"month", 1, 12},
+	{"day of week", 0, 7},
+}
+
+// ValidateCronSchedule checks that expr is either one of the shortcuts such
+// as "@hourly" and "@daily" or a standard cron expression of five fields:
+// minute, hour, day of month, month and day of week. Each field is a comma
+// separated list of "*", values or ranges such as "1-5", optionally
+// followed by a step such as "*/15". Names of months and days are not
+// supported.
+func ValidateCronSchedule(expr string) error {
+	if strings.HasPrefix(expr, "@") {
+		if !cronShortcuts[expr] {
+			return fmt.Errorf("invalid cron schedule %q: unknown shortcut", expr)
+		}
+		return nil
+	}
+	fields := strings.Fields(expr)
+	if len(fields) != len(cronFields) {
+		return fmt.Errorf("invalid cron schedule %q: expected %d fields, got %d", expr, len(cronFields), len(fields))
+	}
+	for i, f := range fields {
+		for _, item := range strings.Split(f, ",") {
+			if err := validateCronItem(item, cronFields[i].min, cronFields[i].max); err != nil {
+				return fmt.Errorf("invalid cron schedule %q: %s field: %v", expr, cronFields[i].name, err)
+			}
+		}
+	}
+	return nil
+}
+
+// validateCronItem checks an item of a cron field list, which is "*", a
+// value or a range, with an optional step.
+func validateCronItem(item string, min, max int) error {
+	rng := item
+	if i := strings.Index(item, "/"); i >= 0 {
+		rng = item[:i]
+		step, err := strconv.Atoi(item[i+1:])
+		if err != nil || step < 1 {
+			return fmt.Errorf("invalid step in %q", item)
+		}
+	}
+	if rng == "*" {
+		return nil
+	}
+	bounds := strings.SplitN(rng, "-", 2)
+	values := make([]int, len(bounds))
+	for i, b := range bounds {
+		v, err := strconv.Atoi(b)
+		if err != nil {
+			return fmt.Errorf("invalid value %q", b)
+		}
+		if v < min || v > max {
+			return fmt.Errorf("value %d out of range %d-%d", v, min, max)
+		}
+		values[i] = v
+	}
+	if len(values) == 2 && values[0] > values[1] {
+		return fmt.Errorf("invalid range %q", rng)
+	}
+	return nil
+}
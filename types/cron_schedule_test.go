@@ -0,0 +1,94 @@
+package types
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+func TestValidateCronSchedule(t *testing.T) {
+	valid := []string{
+		"@hourly",
+		"@daily",
+		"0 3 * * *",
+		"*/15 * * * *",
+		"0 9-17/2 * * 1-5",
+		"30 4 1,15 * 0,7",
+		"0 0 1 1-12/3 *",
+	}
+	for _, expr := range valid {
+		if err := ValidateCronSchedule(expr); err != nil {
+			t.Fatalf("expected %q to be valid, got %v", expr, err)
+		}
+	}
+	invalid := []string{
+		"",
+		"@sometimes",
+		"* * * *",
+		"* * * * * *",
+		"60 * * * *",
+		"* 24 * * *",
+		"* * 0 * *",
+		"* * * 13 *",
+		"* * * * 8",
+		"*/0 * * * *",
+		"5-1 * * * *",
+		"a * * * *",
+		"1,,2 * * * *",
+		"* * * JAN *",
+	}
+	for _, expr := range invalid {
+		if err := ValidateCronSchedule(expr); err == nil {
+			t.Fatalf("expected %q to be invalid", expr)
+		}
+	}
+}
+
+func TestCronCreateRequest(t *testing.T) {
+	req := CronCreateRequest{
+		Name:          "backup",
+		Schedule:      "0 3 * * *",
+		Image:         "hyperhq/backup",
+		Command:       []string{"backup", "--all"},
+		Mail:          "ops@example.com",
+		ContainerName: "backup-job",
+	}
+	cron, err := req.Cron()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cron.Name != "backup" || cron.Schedule != "0 3 * * *" || cron.ContainerName != "backup-job" || cron.OwnerEmail != "ops@example.com" {
+		t.Fatalf("unexpected cron %+v", cron)
+	}
+	if cron.Config.Image != "hyperhq/backup" || !reflect.DeepEqual([]string(cron.Config.Cmd), req.Command) {
+		t.Fatalf("unexpected config %+v", cron.Config)
+	}
+
+	req.Schedule = "nightly"
+	if _, err := req.Cron(); err == nil {
+		t.Fatal("expected an error for an invalid schedule")
+	}
+}
+
+func TestCronListResponse(t *testing.T) {
+	data := `[{"Name":"backup","Schedule":"0 3 * * *","ContainerName":"backup","Config":{"Image":"busybox","Cmd":["tar","czf","/backup/data.tgz","/data"]},"OwnerEmail":"ops@example.com","SuccessCount":4}]`
+	var list CronListResponse
+	if err := json.Unmarshal([]byte(data), &list); err != nil {
+		t.Fatal(err)
+	}
+	if len(list.Crons) != 1 {
+		t.Fatalf("expected 1 cron, got %d", len(list.Crons))
+	}
+	c := list.Crons[0]
+	if c.Name != "backup" || c.Schedule != "0 3 * * *" || c.OwnerEmail != "ops@example.com" || c.SuccessCount != 4 || c.Config == nil || c.Config.Image != "busybox" {
+		t.Fatalf("unexpected cron: %+v", c)
+	}
+
+	b, err := json.Marshal(CronListResponse{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(b) != "[]" {
+		t.Fatalf("expected an empty list to marshal to [], got %s", b)
+	}
+}
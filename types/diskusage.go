@@ -0,0 +1,21 @@
+package types
+
+// Total returns the disk space, in bytes, used by the image layers, the
+// writable layers of the containers, the volumes and the build cache,
+// whether it is reclaimable or in use. The size of the images is counted
+// once, through LayersSize, since their layers are shared.
+func (du DiskUsage) Total() int64 {
+	total := du.LayersSize + du.BuilderSize
+	for _, c := range du.Containers {
+		if c != nil {
+			total += c.SizeRw
+		}
+	}
+	for _, v := range du.Volumes {
+		if v != nil {
+			// The size of volumes is given in GB.
+			total += int64(v.Size) << 30
+		}
+	}
+	return total
+}
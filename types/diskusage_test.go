@@ -0,0 +1,29 @@
+package types
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestDiskUsage(t *testing.T) {
+	var du DiskUsage
+	if err := json.Unmarshal([]byte(`{
+		"LayersSize": 1000000,
+		"Images": [{"Id": "sha256:1", "Size": 600000}, {"Id": "sha256:2", "Size": 700000}],
+		"Containers": [{"Id": "a", "SizeRw": 2048}, {"Id": "b", "SizeRw": 0}],
+		"Volumes": [{"Name": "data", "Size": 10}]
+	}`), &du); err != nil {
+		t.Fatal(err)
+	}
+	if expected := int64(1000000 + 2048 + 10<<30); du.Total() != expected {
+		t.Fatalf("expected a total of %d, got %d", expected, du.Total())
+	}
+
+	du = DiskUsage{}
+	if err := json.Unmarshal([]byte(`{"LayersSize": 10, "Volumes": [], "BuilderSize": 5}`), &du); err != nil {
+		t.Fatal(err)
+	}
+	if du.Containers != nil || du.Volumes == nil || du.Total() != 15 {
+		t.Fatalf("unexpected disk usage %+v", du)
+	}
+}
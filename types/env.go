@@ -0,0 +1,132 @@
+package types
+
+import (
+	"fmt"
+	"io"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// envKey returns the variable name of a "KEY=VALUE" environment entry.
+func envKey(kv string) string {
+	return strings.SplitN(kv, "=", 2)[0]
+}
+
+// SetEnv sets the "KEY=VALUE" entry kv in env, replacing the existing
+// entries for the same key in place or appending it if there is none.
+func SetEnv(env []string, kv string) []string {
+	key := envKey(kv)
+	found := false
+	for i, e := range env {
+		if envKey(e) == key {
+			env[i] = kv
+			found = true
+		}
+	}
+	if !found {
+		env = append(env, kv)
+	}
+	return env
+}
+
+type envByKey []string
+
+func (e envByKey) Len() int           { return len(e) }
+func (e envByKey) Swap(i, j int)      { e[i], e[j] = e[j], e[i] }
+func (e envByKey) Less(i, j int) bool { return envKey(e[i]) < envKey(e[j]) }
+
+// SortEnv returns a copy of env sorted by key. Entries with the same key
+// keep their relative order, and env itself is not modified.
+func SortEnv(env []string) []string {
+	sorted := make([]string, len(env))
+	copy(sorted, env)
+	sort.Stable(envByKey(sorted))
+	return sorted
+}
+
+// EffectiveExecEnv returns the environment a process started with exec
+// would see: the container environment with execEnv applied on top of it
+// through SetEnv. Neither input slice is modified.
+func EffectiveExecEnv(containerEnv, execEnv []string) []string {
+	env := make([]string, len(containerEnv), len(containerEnv)+len(execEnv))
+	copy(env, containerEnv)
+	for _, kv := range execEnv {
+		env = SetEnv(env, kv)
+	}
+	return env
+}
+
+// secretEnvMarkers are the substrings of variable names, in upper case,
+// considered to hold secrets by RedactEnv.
+var secretEnvMarkers = []string{"PASSWORD", "PASSWD", "SECRET", "TOKEN", "KEY", "CREDENTIAL"}
+
+// RedactedEnvValue replaces the value of the variables redacted by RedactEnv.
+const RedactedEnvValue = "********"
+
+// RedactEnv returns a copy of env where the values of the variables whose
+// name looks like it holds a secret are replaced with RedactedEnvValue.
+func RedactEnv(env []string) []string {
+	redacted := make([]string, len(env))
+	for i, kv := range env {
+		redacted[i] = kv
+		if !strings.Contains(kv, "=") {
+			continue
+		}
+		key := strings.ToUpper(envKey(kv))
+		for _, marker := range secretEnvMarkers {
+			if strings.Contains(key, marker) {
+				redacted[i] = envKey(kv) + "=" + RedactedEnvValue
+				break
+			}
+		}
+	}
+	return redacted
+}
+
+// ExportEnvFile writes the container environment to w in the .env file
+// format, one KEY=VALUE entry per line. Secrets are redacted through
+// RedactEnv if redactSecrets is true.
+func (c *ContainerJSON) ExportEnvFile(w io.Writer, redactSecrets bool) error {
+	if c.Config == nil {
+		return nil
+	}
+	env := c.Config.Env
+	if redactSecrets {
+		env = RedactEnv(env)
+	}
+	for _, kv := range env {
+		if _, err := fmt.Fprintln(w, kv); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// secretValueRegexps match environment values that look like plaintext
+// secrets: AWS access key IDs, PEM private keys and long base64 blobs.
+var secretValueRegexps = []*regexp.Regexp{
+	regexp.MustCompile(`\b(AKIA|ASIA)[0-9A-Z]{16}\b`),
+	regexp.MustCompile(`-----BEGIN [A-Z ]*PRIVATE KEY-----`),
+	regexp.MustCompile(`^[A-Za-z0-9+/]{40,}={0,2}$`),
+}
+
+// ScanEnvForSecrets returns the names of the variables of env whose value
+// looks like a plaintext secret. It is a heuristic meant for auditing and
+// may report false positives.
+func ScanEnvForSecrets(env []string) []string {
+	var keys []string
+	for _, kv := range env {
+		parts := strings.SplitN(kv, "=", 2)
+		if len(parts) != 2 {
+			continue
+		}
+		for _, re := range secretValueRegexps {
+			if re.MatchString(parts[1]) {
+				keys = append(keys, parts[0])
+				break
+			}
+		}
+	}
+	return keys
+}
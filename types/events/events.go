@@ -1,5 +1,10 @@
 package events
 
+import (
+	"encoding/json"
+	"io"
+)
+
 const (
 	// ContainerEventType is the event type that containers generate
 	ContainerEventType = "container"
@@ -34,7 +39,26 @@ type Message struct {
 	Type   string
 	Action string
 	Actor  Actor
+	// Scope is the scope of the event, "local" or "swarm".
+	Scope string `json:"scope,omitempty"`
 
 	Time     int64 `json:"time,omitempty"`
 	TimeNano int64 `json:"timeNano,omitempty"`
 }
+
+// MessageDecoder decodes the stream of messages returned by the events
+// endpoint. Blank keep-alive lines between messages are skipped.
+type MessageDecoder struct {
+	dec *json.Decoder
+}
+
+// NewMessageDecoder returns a MessageDecoder reading from r.
+func NewMessageDecoder(r io.Reader) *MessageDecoder {
+	return &MessageDecoder{dec: json.NewDecoder(r)}
+}
+
+// Decode reads the next message of the stream into m. It returns io.EOF
+// at the end of the stream.
+func (d *MessageDecoder) Decode(m *Message) error {
+	return d.dec.Decode(m)
+}
@@ -0,0 +1,35 @@
+package events
+
+import (
+	"io"
+	"strings"
+	"testing"
+)
+
+func TestMessageDecoder(t *testing.T) {
+	stream := `{"Type":"container","Action":"die","Actor":{"ID":"abc","Attributes":{"exitCode":"1","name":"web"}},"scope":"local","time":1485000000,"timeNano":1485000000123456789}
+
+{"Type":"container","Action":"health_status: unhealthy","Actor":{"ID":"abc"},"time":1485000001}
+`
+	d := NewMessageDecoder(strings.NewReader(stream))
+
+	var m Message
+	if err := d.Decode(&m); err != nil {
+		t.Fatal(err)
+	}
+	if m.Type != ContainerEventType || m.Action != "die" || m.Actor.ID != "abc" || m.Actor.Attributes["exitCode"] != "1" || m.Scope != "local" || m.TimeNano != 1485000000123456789 {
+		t.Fatalf("unexpected message: %+v", m)
+	}
+
+	m = Message{}
+	if err := d.Decode(&m); err != nil {
+		t.Fatal(err)
+	}
+	if m.Action != "health_status: unhealthy" || m.Time != 1485000001 {
+		t.Fatalf("unexpected message: %+v", m)
+	}
+
+	if err := d.Decode(&m); err != io.EOF {
+		t.Fatalf("expected io.EOF, got %v", err)
+	}
+}
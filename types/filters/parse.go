@@ -7,6 +7,7 @@ import (
 	"errors"
 	"fmt"
 	"regexp"
+	"sort"
 	"strings"
 
 	"github.com/hyperhq/hyper-api/types/versions"
@@ -255,6 +256,41 @@ func (filters Args) Include(field string) bool {
 	return ok
 }
 
+// Contains returns true if the filters have at least one value for the
+// field. It is the same as Include and reads better in client-side filtering.
+func (filters Args) Contains(field string) bool {
+	return filters.Include(field)
+}
+
+// MarshalJSON encodes the filters as the map[string][]string the daemon
+// expects, with the values of each field sorted.
+func (filters Args) MarshalJSON() ([]byte, error) {
+	m := convertArgsToSlice(filters.fields)
+	for _, values := range m {
+		sort.Strings(values)
+	}
+	return json.Marshal(m)
+}
+
+// UnmarshalJSON decodes filters encoded either as map[string][]string or
+// as map[string]map[string]bool.
+func (filters *Args) UnmarshalJSON(data []byte) error {
+	if string(data) == "null" {
+		*filters = NewArgs()
+		return nil
+	}
+	m := map[string]map[string]bool{}
+	if err := json.Unmarshal(data, &m); err != nil {
+		deprecated := map[string][]string{}
+		if deprecatedErr := json.Unmarshal(data, &deprecated); deprecatedErr != nil {
+			return err
+		}
+		m = deprecatedArgs(deprecated)
+	}
+	*filters = Args{m}
+	return nil
+}
+
 // Validate ensures that all the fields in the filter are valid.
 // It returns an error as soon as it finds an invalid field.
 func (filters Args) Validate(accepted map[string]bool) error {
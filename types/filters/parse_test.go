@@ -1,6 +1,7 @@
 package filters
 
 import (
+	"encoding/json"
 	"fmt"
 	"testing"
 )
@@ -415,3 +416,55 @@ func TestFuzzyMatch(t *testing.T) {
 		}
 	}
 }
+
+func TestContains(t *testing.T) {
+	f := NewArgs()
+	if f.Contains("status") {
+		t.Fatalf("Expected to not contain a status key, got true")
+	}
+	f.Add("status", "running")
+	if !f.Contains("status") {
+		t.Fatalf("Expected to contain a status key, got false")
+	}
+}
+
+func TestMarshalJSON(t *testing.T) {
+	f := NewArgs()
+	f.Add("status", "running")
+	f.Add("status", "paused")
+	f.Add("label", "env=prod")
+	buf, err := json.Marshal(f)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := `{"label":["env=prod"],"status":["paused","running"]}`
+	if string(buf) != expected {
+		t.Fatalf("Expected %s, got %s", expected, buf)
+	}
+}
+
+func TestUnmarshalJSON(t *testing.T) {
+	valids := []string{
+		`{"status":["paused","running"]}`,
+		`{"status":{"paused":true,"running":true}}`,
+	}
+	for _, v := range valids {
+		var f Args
+		if err := json.Unmarshal([]byte(v), &f); err != nil {
+			t.Fatalf("%s: %v", v, err)
+		}
+		if !f.ExactMatch("status", "paused") || !f.ExactMatch("status", "running") || f.Len() != 1 {
+			t.Fatalf("%s: unexpected filters %v", v, f.fields)
+		}
+	}
+
+	var f Args
+	if err := json.Unmarshal([]byte("null"), &f); err != nil {
+		t.Fatal(err)
+	}
+	f.Add("status", "running")
+
+	if err := json.Unmarshal([]byte(`{"status":"running"}`), &f); err == nil {
+		t.Fatalf("Expected an error for an invalid filter encoding")
+	}
+}
@@ -0,0 +1,115 @@
+package types
+
+import (
+	"encoding/json"
+	"net/url"
+	"sort"
+	"strconv"
+)
+
+// FloatingIP is a floating IP as listed by the /fips endpoint.
+type FloatingIP struct {
+	IP        string `json:"fip"`
+	Container string `json:"container"`
+	Name      string `json:"name"`
+	Created   string `json:"created"` // Created is the creation time as sent by the daemon
+
+	// Region is the region the floating IP belongs to. It is set on the
+	// client side by MergeFIPInventory.
+	Region string `json:"region,omitempty"`
+}
+
+// MergeFIPInventory tags the floating IPs of each region with their region
+// and returns them as a single slice sorted by region, then by IP.
+func MergeFIPInventory(byRegion map[string][]*FloatingIP) []*FloatingIP {
+	var merged []*FloatingIP
+	for region, fips := range byRegion {
+		for _, fip := range fips {
+			if fip == nil {
+				continue
+			}
+			fip.Region = region
+			merged = append(merged, fip)
+		}
+	}
+	sort.Stable(fipsByRegion(merged))
+	return merged
+}
+
+type fipsByRegion []*FloatingIP
+
+func (s fipsByRegion) Len() int      { return len(s) }
+func (s fipsByRegion) Swap(i, j int) { s[i], s[j] = s[j], s[i] }
+func (s fipsByRegion) Less(i, j int) bool {
+	if s[i].Region != s[j].Region {
+		return s[i].Region < s[j].Region
+	}
+	return s[i].IP < s[j].IP
+}
+
+// FloatingIPAllocateRequest is the request to allocate new floating IPs.
+type FloatingIPAllocateRequest struct {
+	Count int `json:"count"`
+}
+
+// ToQuery encodes the request as the query parameters of the
+// /fips/allocate endpoint.
+func (r FloatingIPAllocateRequest) ToQuery() url.Values {
+	query := url.Values{}
+	query.Set("count", strconv.Itoa(r.Count))
+	return query
+}
+
+// FloatingIPAllocateResponse holds the floating IPs allocated by a
+// FloatingIPAllocateRequest. It is encoded as a bare JSON array.
+type FloatingIPAllocateResponse struct {
+	FIPs []string
+}
+
+// MarshalJSON implements json.Marshaler.
+func (r FloatingIPAllocateResponse) MarshalJSON() ([]byte, error) {
+	if r.FIPs == nil {
+		return []byte("[]"), nil
+	}
+	return json.Marshal(r.FIPs)
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (r *FloatingIPAllocateResponse) UnmarshalJSON(data []byte) error {
+	return json.Unmarshal(data, &r.FIPs)
+}
+
+// FloatingIPListResponse holds the floating IPs returned by the /fips
+// endpoint. It is encoded as a bare JSON array.
+type FloatingIPListResponse struct {
+	FIPs []FloatingIP
+}
+
+// MarshalJSON implements json.Marshaler.
+func (r FloatingIPListResponse) MarshalJSON() ([]byte, error) {
+	if r.FIPs == nil {
+		return []byte("[]"), nil
+	}
+	return json.Marshal(r.FIPs)
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (r *FloatingIPListResponse) UnmarshalJSON(data []byte) error {
+	return json.Unmarshal(data, &r.FIPs)
+}
+
+// FloatingIPAttachRequest is the request to attach a floating IP to a
+// container.
+type FloatingIPAttachRequest struct {
+	IP        string `json:"ip"`
+	Container string `json:"container"`
+}
+
+// ToQuery encodes the request as the query parameters of the
+// /fips/attach endpoint.
+func (r FloatingIPAttachRequest) ToQuery() url.Values {
+	query := url.Values{}
+	query.Set("ip", r.IP)
+	query.Set("container", r.Container)
+	return query
+}
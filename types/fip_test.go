@@ -0,0 +1,103 @@
+package types
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+func TestFloatingIPAllocateAndAttach(t *testing.T) {
+	if q := (FloatingIPAllocateRequest{Count: 2}).ToQuery(); q.Get("count") != "2" {
+		t.Fatalf("unexpected query: %v", q)
+	}
+
+	var allocated FloatingIPAllocateResponse
+	if err := json.Unmarshal([]byte(`["209.177.88.1","209.177.88.2"]`), &allocated); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(allocated.FIPs, []string{"209.177.88.1", "209.177.88.2"}) {
+		t.Fatalf("unexpected FIPs: %v", allocated.FIPs)
+	}
+	b, err := json.Marshal(allocated)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(b) != `["209.177.88.1","209.177.88.2"]` {
+		t.Fatalf("unexpected JSON: %s", b)
+	}
+
+	attach := FloatingIPAttachRequest{IP: allocated.FIPs[0], Container: "web"}
+	q := attach.ToQuery()
+	if q.Get("ip") != "209.177.88.1" || q.Get("container") != "web" {
+		t.Fatalf("unexpected query: %v", q)
+	}
+}
+
+func TestFloatingIPListResponse(t *testing.T) {
+	data := `[{"fip":"209.177.88.1","container":"web","name":"front","created":"2017-01-02T03:04:05Z"},{"fip":"209.177.88.2","container":"","name":""}]`
+	var list FloatingIPListResponse
+	if err := json.Unmarshal([]byte(data), &list); err != nil {
+		t.Fatal(err)
+	}
+	if len(list.FIPs) != 2 {
+		t.Fatalf("expected 2 FIPs, got %d", len(list.FIPs))
+	}
+	expected := FloatingIP{IP: "209.177.88.1", Container: "web", Name: "front", Created: "2017-01-02T03:04:05Z"}
+	if !reflect.DeepEqual(list.FIPs[0], expected) {
+		t.Fatalf("expected %+v, got %+v", expected, list.FIPs[0])
+	}
+
+	b, err := json.Marshal(FloatingIPListResponse{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(b) != "[]" {
+		t.Fatalf("expected an empty list to marshal to [], got %s", b)
+	}
+}
+
+func TestFloatingIPListResponseFromFipList(t *testing.T) {
+	// The /fips endpoint only sends string values, as FipList in the
+	// client decodes them into []map[string]string.
+	data := `[{"fip":"209.177.88.1","container":"web","name":"front","created":"2017-01-02 03:04:05 +0000 UTC"},{"fip":"209.177.88.2","container":"","name":"","created":""}]`
+	var raw []map[string]string
+	if err := json.Unmarshal([]byte(data), &raw); err != nil {
+		t.Fatal(err)
+	}
+	var list FloatingIPListResponse
+	if err := json.Unmarshal([]byte(data), &list); err != nil {
+		t.Fatal(err)
+	}
+	if len(list.FIPs) != len(raw) {
+		t.Fatalf("expected %d FIPs, got %d", len(raw), len(list.FIPs))
+	}
+	for i, fip := range list.FIPs {
+		if fip.IP != raw[i]["fip"] || fip.Container != raw[i]["container"] || fip.Name != raw[i]["name"] || fip.Created != raw[i]["created"] {
+			t.Fatalf("%d: expected %v, got %+v", i, raw[i], fip)
+		}
+	}
+}
+
+func TestMergeFIPInventory(t *testing.T) {
+	byRegion := map[string][]*FloatingIP{
+		"us-west-1":    {{IP: "209.177.88.2"}, {IP: "209.177.88.1"}},
+		"eu-central-1": {{IP: "185.34.1.7"}, nil},
+	}
+	merged := MergeFIPInventory(byRegion)
+	expected := []FloatingIP{
+		{IP: "185.34.1.7", Region: "eu-central-1"},
+		{IP: "209.177.88.1", Region: "us-west-1"},
+		{IP: "209.177.88.2", Region: "us-west-1"},
+	}
+	if len(merged) != len(expected) {
+		t.Fatalf("expected %d FIPs, got %d", len(expected), len(merged))
+	}
+	for i, fip := range merged {
+		if *fip != expected[i] {
+			t.Fatalf("%d: expected %+v, got %+v", i, expected[i], *fip)
+		}
+	}
+	if byRegion["us-west-1"][0].Region != "us-west-1" {
+		t.Fatal("expected the FIPs to be tagged in place")
+	}
+}
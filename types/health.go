@@ -0,0 +1,128 @@
+package types
+
+import (
+	"strings"
+	"time"
+
+	"github.com/hyperhq/hyper-api/types/container"
+	"github.com/hyperhq/hyper-api/types/events"
+)
+
+// Health states of a container with a health check.
+const (
+	Starting  = "starting"
+	Healthy   = "healthy"
+	Unhealthy = "unhealthy"
+)
+
+// HealthcheckResult stores information about a single run of a health check
+// probe.
+type HealthcheckResult struct {
+	Start    time.Time // Start is the time this check started
+	End      time.Time // End is the time this check ended
+	ExitCode int       // ExitCode meanings: 0=healthy, 1=unhealthy, 2=reserved (considered unhealthy), else=error running probe
+	Output   string    // Output from last check
+}
+
+// Health stores the result of the health check of a container.
+type Health struct {
+	Status        string              // Status is one of Starting, Healthy or Unhealthy
+	FailingStreak int                 // FailingStreak is the number of consecutive failures
+	Log           []HealthcheckResult // Log contains the last few results (oldest first)
+}
+
+// IsHealthy indicates whether the health check of the container reports it
+// healthy. A running container without a health check is considered
+// healthy.
+func (s *ContainerState) IsHealthy() bool {
+	if s.Health == nil {
+		return s.Running
+	}
+	return s.Health.Status == Healthy
+}
+
+// healthStatusAction is the prefix of the action of the events emitted when
+// the health status of a container changes, e.g. "health_status: healthy".
+const healthStatusAction = "health_status:"
+
+const (
+	// defaultHealthInterval is the interval of the health checks of the
+	// daemon when the healthcheck of a container does not set one.
+	defaultHealthInterval = 30 * time.Second
+	// defaultReadinessPoll is the polling interval of ReadinessSchedule for
+	// containers without a healthcheck.
+	defaultReadinessPoll = time.Second
+)
+
+// ReadinessSchedule returns the offsets, from the start of the container,
+// at which to poll it for readiness. They are aligned to the interval of
+// the health checks, so that each poll sees a new result, and end at max.
+// Containers without a healthcheck, or with a disabled one, are polled
+// every second.
+func ReadinessSchedule(hc *container.HealthConfig, max time.Duration) []time.Duration {
+	if max <= 0 {
+		return nil
+	}
+	interval := defaultReadinessPoll
+	if hc != nil && len(hc.Test) > 0 && hc.Test[0] != "NONE" {
+		interval = hc.Interval
+		if interval <= 0 {
+			interval = defaultHealthInterval
+		}
+	}
+	var offsets []time.Duration
+	for d := interval; d < max; d += interval {
+		offsets = append(offsets, d)
+	}
+	return append(offsets, max)
+}
+
+// HealthTransition is a change of the health status of a container.
+type HealthTransition struct {
+	ContainerID string
+	From        string // From is the previous status, or "" if it is unknown
+	To          string
+	Time        time.Time
+}
+
+// HealthTransitions extracts the health status changes of containers from
+// msgs, which are in the order of the events stream. Other events are
+// ignored, and so are repeated events reporting an unchanged status.
+func HealthTransitions(msgs []events.Message) []HealthTransition {
+	var transitions []HealthTransition
+	last := map[string]string{}
+	for _, m := range msgs {
+		if m.Type != "" && m.Type != events.ContainerEventType {
+			continue
+		}
+		if !strings.HasPrefix(m.Action, healthStatusAction) {
+			continue
+		}
+		id := m.Actor.ID
+		if id == "" {
+			id = m.ID
+		}
+		status := strings.TrimSpace(strings.TrimPrefix(m.Action, healthStatusAction))
+		from, seen := last[id]
+		if seen && from == status {
+			continue
+		}
+		last[id] = status
+		transitions = append(transitions, HealthTransition{
+			ContainerID: id,
+			From:        from,
+			To:          status,
+			Time:        messageTime(m),
+		})
+	}
+	return transitions
+}
+
+// messageTime returns the time of the event, using its nanosecond
+// timestamp when set.
+func messageTime(m events.Message) time.Time {
+	if m.TimeNano != 0 {
+		return time.Unix(0, m.TimeNano)
+	}
+	return time.Unix(m.Time, 0)
+}
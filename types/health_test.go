@@ -0,0 +1,104 @@
+package types
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hyperhq/hyper-api/types/container"
+	"github.com/hyperhq/hyper-api/types/events"
+)
+
+func TestContainerStateIsHealthy(t *testing.T) {
+	testCases := []struct {
+		state    ContainerState
+		expected bool
+	}{
+		{ContainerState{Running: true}, true},
+		{ContainerState{}, false},
+		{ContainerState{Running: true, Health: &Health{Status: Healthy}}, true},
+		{ContainerState{Running: true, Health: &Health{Status: Starting}}, false},
+		{ContainerState{Running: true, Health: &Health{Status: Unhealthy, FailingStreak: 3}}, false},
+	}
+	for i, c := range testCases {
+		if healthy := c.state.IsHealthy(); healthy != c.expected {
+			t.Fatalf("%d: expected %v, got %v", i, c.expected, healthy)
+		}
+	}
+}
+
+func TestContainerStateHealthJSON(t *testing.T) {
+	var s ContainerState
+	if err := json.Unmarshal([]byte(`{"Status":"running","Running":true}`), &s); err != nil {
+		t.Fatal(err)
+	}
+	if s.Health != nil {
+		t.Fatalf("expected no health, got %+v", s.Health)
+	}
+	b, err := json.Marshal(s)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(string(b), "Health") {
+		t.Fatalf("expected health to be omitted, got %s", b)
+	}
+
+	data := `{"Running":true,"Health":{"Status":"unhealthy","FailingStreak":2,"Log":[{"Start":"2017-01-02T03:04:05Z","End":"2017-01-02T03:04:06Z","ExitCode":1,"Output":"connection refused"}]}}`
+	if err := json.Unmarshal([]byte(data), &s); err != nil {
+		t.Fatal(err)
+	}
+	if s.Health == nil || s.Health.Status != Unhealthy || s.Health.FailingStreak != 2 || len(s.Health.Log) != 1 {
+		t.Fatalf("unexpected health: %+v", s.Health)
+	}
+	if r := s.Health.Log[0]; r.ExitCode != 1 || r.Output != "connection refused" || r.End.Sub(r.Start) != time.Second {
+		t.Fatalf("unexpected health check result: %+v", r)
+	}
+}
+
+func TestHealthTransitions(t *testing.T) {
+	msgs := []events.Message{
+		{Type: events.ContainerEventType, Action: "start", Actor: events.Actor{ID: "web"}, Time: 1485000000},
+		{Type: events.ContainerEventType, Action: "health_status: starting", Actor: events.Actor{ID: "web"}, Time: 1485000001},
+		{Type: events.ContainerEventType, Action: "exec_start: /bin/sh -c curl -f localhost", Actor: events.Actor{ID: "web"}, Time: 1485000005},
+		{Type: events.ContainerEventType, Action: "health_status: healthy", Actor: events.Actor{ID: "web"}, Time: 1485000010, TimeNano: 1485000010500000000},
+		{Type: events.ContainerEventType, Action: "health_status: healthy", Actor: events.Actor{ID: "web"}, Time: 1485000040},
+		{Type: events.ImageEventType, Action: "health_status: healthy", Actor: events.Actor{ID: "busybox"}, Time: 1485000041},
+		{Status: "health_status: unhealthy", ID: "db", Action: "health_status: unhealthy", Time: 1485000050},
+	}
+	expected := []HealthTransition{
+		{ContainerID: "web", From: "", To: Starting, Time: time.Unix(1485000001, 0)},
+		{ContainerID: "web", From: Starting, To: Healthy, Time: time.Unix(1485000010, 500000000)},
+		{ContainerID: "db", From: "", To: Unhealthy, Time: time.Unix(1485000050, 0)},
+	}
+	if transitions := HealthTransitions(msgs); !reflect.DeepEqual(transitions, expected) {
+		t.Fatalf("expected %+v, got %+v", expected, transitions)
+	}
+	if transitions := HealthTransitions(nil); transitions != nil {
+		t.Fatalf("expected no transitions, got %+v", transitions)
+	}
+}
+
+func TestReadinessSchedule(t *testing.T) {
+	s := time.Second
+	hc := &container.HealthConfig{Test: []string{"CMD", "true"}, Interval: 5 * s}
+	testCases := []struct {
+		hc       *container.HealthConfig
+		max      time.Duration
+		expected []time.Duration
+	}{
+		{hc, 17 * s, []time.Duration{5 * s, 10 * s, 15 * s, 17 * s}},
+		{hc, 15 * s, []time.Duration{5 * s, 10 * s, 15 * s}},
+		{hc, 2 * s, []time.Duration{2 * s}},
+		{&container.HealthConfig{Test: []string{"CMD-SHELL", "true"}}, 70 * s, []time.Duration{30 * s, 60 * s, 70 * s}},
+		{&container.HealthConfig{Test: []string{"NONE"}, Interval: 5 * s}, 3 * s, []time.Duration{s, 2 * s, 3 * s}},
+		{nil, 2500 * time.Millisecond, []time.Duration{s, 2 * s, 2500 * time.Millisecond}},
+		{hc, 0, nil},
+	}
+	for _, c := range testCases {
+		if schedule := ReadinessSchedule(c.hc, c.max); !reflect.DeepEqual(schedule, c.expected) {
+			t.Fatalf("expected %v, got %v", c.expected, schedule)
+		}
+	}
+}
@@ -0,0 +1,115 @@
+package types
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/hyperhq/hyper-api/types/container"
+)
+
+// Labels set by Hyper.sh on containers to carry instance metadata.
+const (
+	// HyperInstanceTypeLabel holds the container size, e.g. "s4"
+	HyperInstanceTypeLabel = "sh.hyper.instancetype"
+	// HyperZoneLabel holds the zone the container is placed in
+	HyperZoneLabel = "sh.hyper.zone"
+	// HyperBillingGroupLabel holds the billing group the container is charged to
+	HyperBillingGroupLabel = "sh.hyper.billing-group"
+	// HyperProtectedLabel marks a container as protected from removal
+	HyperProtectedLabel = "sh.hyper.protected"
+)
+
+// KnownInstanceTypes lists the container sizes offered by Hyper.sh.
+var KnownInstanceTypes = []string{"s1", "s2", "s3", "s4", "m1", "m2", "m3", "l1", "l2", "l3"}
+
+// KnownZones lists the zones containers can be placed in on Hyper.sh.
+var KnownZones = []string{"us-west-1a", "us-west-1b", "us-west-1c", "eu-central-1a", "eu-central-1b"}
+
+// HyperContainerMeta holds the instance metadata Hyper.sh attaches to a
+// container through its sh.hyper.* labels.
+type HyperContainerMeta struct {
+	InstanceType string
+	Zone         string
+	BillingGroup string
+	Protected    bool
+}
+
+// ParseHyperMetadata extracts the Hyper.sh instance metadata from labels.
+// Missing labels leave the corresponding fields empty, and a protected
+// label that is not a valid boolean is treated as false.
+func ParseHyperMetadata(labels map[string]string) HyperContainerMeta {
+	meta := HyperContainerMeta{
+		InstanceType: labels[HyperInstanceTypeLabel],
+		Zone:         labels[HyperZoneLabel],
+		BillingGroup: labels[HyperBillingGroupLabel],
+	}
+	if v, ok := labels[HyperProtectedLabel]; ok {
+		meta.Protected, _ = strconv.ParseBool(v)
+	}
+	return meta
+}
+
+// HyperMeta returns the Hyper.sh instance metadata of the container.
+func (c Container) HyperMeta() HyperContainerMeta {
+	return ParseHyperMetadata(c.Labels)
+}
+
+// ParseHyperSize normalizes a container size given to --size, such as
+// "S4", to its canonical instance type token and checks that it is one of
+// KnownInstanceTypes.
+func ParseHyperSize(s string) (string, error) {
+	size := strings.ToLower(strings.TrimSpace(s))
+	for _, t := range KnownInstanceTypes {
+		if size == t {
+			return t, nil
+		}
+	}
+	return "", fmt.Errorf("invalid container size %q: must be one of %s", s, strings.Join(KnownInstanceTypes, ", "))
+}
+
+// SetHyperSize sets the size of the container to create through the
+// HyperInstanceTypeLabel label.
+func (cfg *ContainerCreateConfig) SetHyperSize(size string) error {
+	instanceType, err := ParseHyperSize(size)
+	if err != nil {
+		return err
+	}
+	if cfg.Config == nil {
+		cfg.Config = &container.Config{}
+	}
+	if cfg.Config.Labels == nil {
+		cfg.Config.Labels = map[string]string{}
+	}
+	cfg.Config.Labels[HyperInstanceTypeLabel] = instanceType
+	return nil
+}
+
+// SetZone pins the container to create to zone, which must be one of
+// KnownZones, through the HyperZoneLabel label.
+func SetZone(cfg *ContainerCreateConfig, zone string) error {
+	known := false
+	for _, z := range KnownZones {
+		if zone == z {
+			known = true
+			break
+		}
+	}
+	if !known {
+		return fmt.Errorf("invalid zone %q: must be one of %s", zone, strings.Join(KnownZones, ", "))
+	}
+	if cfg.Config == nil {
+		cfg.Config = &container.Config{}
+	}
+	if cfg.Config.Labels == nil {
+		cfg.Config.Labels = map[string]string{}
+	}
+	cfg.Config.Labels[HyperZoneLabel] = zone
+	return nil
+}
+
+// ZoneOf returns the zone the container is placed in, or an empty string
+// if it is unknown.
+func ZoneOf(c Container) string {
+	return c.Labels[HyperZoneLabel]
+}
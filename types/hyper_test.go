@@ -0,0 +1,84 @@
+package types
+
+import "testing"
+
+func TestParseHyperMetadata(t *testing.T) {
+	c := Container{
+		Labels: map[string]string{
+			"sh.hyper.instancetype":  "s4",
+			"sh.hyper.zone":          "us-west-1a",
+			"sh.hyper.billing-group": "infra",
+			"sh.hyper.protected":     "true",
+			"app":                    "web",
+		},
+	}
+	expected := HyperContainerMeta{
+		InstanceType: "s4",
+		Zone:         "us-west-1a",
+		BillingGroup: "infra",
+		Protected:    true,
+	}
+	if meta := c.HyperMeta(); meta != expected {
+		t.Fatalf("expected %+v, got %+v", expected, meta)
+	}
+
+	meta := ParseHyperMetadata(map[string]string{"sh.hyper.protected": "maybe"})
+	if meta.Protected {
+		t.Fatal("expected an invalid protected label to be ignored")
+	}
+	if meta := ParseHyperMetadata(nil); meta != (HyperContainerMeta{}) {
+		t.Fatalf("expected empty metadata for nil labels, got %+v", meta)
+	}
+}
+
+func TestParseHyperSize(t *testing.T) {
+	size, err := ParseHyperSize("S4")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if size != "s4" {
+		t.Fatalf("expected s4, got %s", size)
+	}
+	if _, err := ParseHyperSize("xxl"); err == nil {
+		t.Fatal("expected an error for an unknown size")
+	}
+}
+
+func TestSetHyperSize(t *testing.T) {
+	var cfg ContainerCreateConfig
+	if err := cfg.SetHyperSize("M2"); err != nil {
+		t.Fatal(err)
+	}
+	if label := cfg.Config.Labels["sh.hyper.instancetype"]; label != "m2" {
+		t.Fatalf("expected instance type label m2, got %q", label)
+	}
+	if err := cfg.SetHyperSize("s9"); err == nil {
+		t.Fatal("expected an error for an unknown size")
+	}
+	if label := cfg.Config.Labels["sh.hyper.instancetype"]; label != "m2" {
+		t.Fatalf("expected the label to be unchanged, got %q", label)
+	}
+}
+
+func TestSetZone(t *testing.T) {
+	var cfg ContainerCreateConfig
+	if err := SetZone(&cfg, "us-west-1b"); err != nil {
+		t.Fatal(err)
+	}
+	if zone := cfg.Config.Labels[HyperZoneLabel]; zone != "us-west-1b" {
+		t.Fatalf("expected zone us-west-1b, got %q", zone)
+	}
+	if zone := ZoneOf(Container{Labels: cfg.Config.Labels}); zone != "us-west-1b" {
+		t.Fatalf("expected zone us-west-1b, got %q", zone)
+	}
+
+	if err := SetZone(&cfg, "mars-1a"); err == nil {
+		t.Fatal("expected an error for an unknown zone")
+	}
+	if zone := cfg.Config.Labels[HyperZoneLabel]; zone != "us-west-1b" {
+		t.Fatalf("expected zone to be left unchanged, got %q", zone)
+	}
+	if zone := ZoneOf(Container{}); zone != "" {
+		t.Fatalf("expected no zone, got %q", zone)
+	}
+}
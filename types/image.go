@@ -0,0 +1,151 @@
+package types
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// PullPolicy represents when an image should be pulled before a container
+// is created from it.
+type PullPolicy string
+
+const (
+	// PullAlways pulls the image even if it is already present
+	PullAlways PullPolicy = "Always"
+	// PullIfNotPresent pulls the image only when it is not present
+	PullIfNotPresent PullPolicy = "IfNotPresent"
+	// PullNever never pulls the image
+	PullNever PullPolicy = "Never"
+)
+
+// ShouldPull resolves policy against whether the image is already present.
+// It returns an error when the image is absent and the policy forbids
+// pulling it, or when the policy is unknown.
+func ShouldPull(policy PullPolicy, present bool) (bool, error) {
+	switch policy {
+	case PullAlways:
+		return true, nil
+	case PullIfNotPresent:
+		return !present, nil
+	case PullNever:
+		if !present {
+			return false, fmt.Errorf("image is not present and pull policy is %s", policy)
+		}
+		return false, nil
+	}
+	return false, fmt.Errorf("invalid pull policy: %q", policy)
+}
+
+const (
+	shellPrefix = "/bin/sh -c "
+	nopMarker   = "#(nop)"
+)
+
+// ReconstructDockerfile approximates the Dockerfile an image was built from
+// using its history, as returned by the image history endpoint (newest
+// first). Metadata instructions recorded as "#(nop)" are emitted as is and
+// every other command is wrapped in a RUN instruction. The lines are
+// returned oldest first, and entries without a CreatedBy are skipped.
+func ReconstructDockerfile(history []ImageHistory) []string {
+	lines := []string{}
+	for i := len(history) - 1; i >= 0; i-- {
+		createdBy := strings.TrimSpace(history[i].CreatedBy)
+		if createdBy == "" {
+			continue
+		}
+		if idx := strings.Index(createdBy, nopMarker); idx >= 0 {
+			lines = append(lines, strings.TrimSpace(createdBy[idx+len(nopMarker):]))
+			continue
+		}
+		lines = append(lines, "RUN "+strings.TrimPrefix(createdBy, shellPrefix))
+	}
+	return lines
+}
+
+// EstimatedPullSize estimates how many bytes pulling an image would
+// download by summing the sizes of the layers in its history, skipping
+// empty layers. Layers are counted uncompressed and layers already present
+// locally are not subtracted, so the result is an upper bound.
+func EstimatedPullSize(history []ImageHistory) int64 {
+	var size int64
+	for _, h := range history {
+		if h.Size > 0 {
+			size += h.Size
+		}
+	}
+	return size
+}
+
+// RemovableImages splits images into those no container uses, which can be
+// removed, and those used by at least one of containers, as determined by
+// the container ImageID.
+func RemovableImages(images []Image, containers []Container) (removable, inUse []Image) {
+	used := make(map[string]bool, len(containers))
+	for _, c := range containers {
+		used[c.ImageID] = true
+	}
+	for _, img := range images {
+		if used[img.ID] {
+			inUse = append(inUse, img)
+		} else {
+			removable = append(removable, img)
+		}
+	}
+	return removable, inUse
+}
+
+// TagGCCandidates returns the tags to remove to keep only the keepPerRepo
+// most recently created images of each repository. The result is sorted.
+func TagGCCandidates(images []Image, keepPerRepo int) []string {
+	byRepo := map[string][]taggedImage{}
+	for _, img := range images {
+		for _, tag := range img.RepoTags {
+			if tag == "<none>:<none>" {
+				continue
+			}
+			repo := tagRepository(tag)
+			byRepo[repo] = append(byRepo[repo], taggedImage{tag: tag, created: img.Created})
+		}
+	}
+	if keepPerRepo < 0 {
+		keepPerRepo = 0
+	}
+	var candidates []string
+	for _, tags := range byRepo {
+		if len(tags) <= keepPerRepo {
+			continue
+		}
+		sort.Sort(newestFirst(tags))
+		for _, t := range tags[keepPerRepo:] {
+			candidates = append(candidates, t.tag)
+		}
+	}
+	sort.Strings(candidates)
+	return candidates
+}
+
+// tagRepository returns the repository part of a "repository:tag"
+// reference, taking care of registry hosts with a port.
+func tagRepository(tag string) string {
+	if i := strings.LastIndex(tag, ":"); i > strings.LastIndex(tag, "/") {
+		return tag[:i]
+	}
+	return tag
+}
+
+type taggedImage struct {
+	tag     string
+	created int64
+}
+
+type newestFirst []taggedImage
+
+func (s newestFirst) Len() int      { return len(s) }
+func (s newestFirst) Swap(i, j int) { s[i], s[j] = s[j], s[i] }
+func (s newestFirst) Less(i, j int) bool {
+	if s[i].created != s[j].created {
+		return s[i].created > s[j].created
+	}
+	return s[i].tag < s[j].tag
+}
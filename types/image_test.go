@@ -0,0 +1,105 @@
+package types
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestShouldPull(t *testing.T) {
+	testCases := []struct {
+		policy        PullPolicy
+		present       bool
+		expected      bool
+		expectedError bool
+	}{
+		{PullAlways, true, true, false},
+		{PullAlways, false, true, false},
+		{PullIfNotPresent, true, false, false},
+		{PullIfNotPresent, false, true, false},
+		{PullNever, true, false, false},
+		{PullNever, false, false, true},
+		{"Sometimes", true, false, true},
+	}
+	for _, c := range testCases {
+		pull, err := ShouldPull(c.policy, c.present)
+		if err != nil && !c.expectedError {
+			t.Fatalf("%s/%v: unexpected error: %v", c.policy, c.present, err)
+		}
+		if err == nil && c.expectedError {
+			t.Fatalf("%s/%v: expected an error", c.policy, c.present)
+		}
+		if pull != c.expected {
+			t.Fatalf("%s/%v: expected %v, got %v", c.policy, c.present, c.expected, pull)
+		}
+	}
+}
+
+func TestReconstructDockerfile(t *testing.T) {
+	history := []ImageHistory{
+		{ID: "sha256:3", CreatedBy: "/bin/sh -c apt-get update && apt-get install -y curl"},
+		{ID: "<missing>", CreatedBy: "/bin/sh -c #(nop)  ENV LANG=C.UTF-8"},
+		{ID: "<missing>", CreatedBy: ""},
+		{ID: "<missing>", CreatedBy: "/bin/sh -c #(nop) ADD file:4a3f2d in / "},
+	}
+	expected := []string{
+		"ADD file:4a3f2d in /",
+		"ENV LANG=C.UTF-8",
+		"RUN apt-get update && apt-get install -y curl",
+	}
+	actual := ReconstructDockerfile(history)
+	if len(actual) != len(expected) {
+		t.Fatalf("expected %q, got %q", expected, actual)
+	}
+	for i := range expected {
+		if actual[i] != expected[i] {
+			t.Fatalf("line %d: expected %q, got %q", i, expected[i], actual[i])
+		}
+	}
+}
+
+func TestEstimatedPullSize(t *testing.T) {
+	history := []ImageHistory{
+		{CreatedBy: "/bin/sh -c #(nop)  CMD [\"nginx\"]", Size: 0},
+		{CreatedBy: "/bin/sh -c apt-get install -y nginx", Size: 58 * 1024 * 1024},
+		{CreatedBy: "/bin/sh -c #(nop)  ENV NGINX_VERSION=1.11", Size: 0},
+		{CreatedBy: "/bin/sh -c #(nop) ADD file:4a3f2d in / ", Size: 125 * 1024 * 1024},
+	}
+	if size := EstimatedPullSize(history); size != 183*1024*1024 {
+		t.Fatalf("expected %d, got %d", 183*1024*1024, size)
+	}
+	if size := EstimatedPullSize(nil); size != 0 {
+		t.Fatalf("expected 0 for an empty history, got %d", size)
+	}
+}
+
+func TestRemovableImages(t *testing.T) {
+	images := []Image{
+		{ID: "sha256:nginx", RepoTags: []string{"nginx:latest"}},
+		{ID: "sha256:redis", RepoTags: []string{"redis:3"}},
+	}
+	containers := []Container{
+		{ID: "ed3d5c3a9e11", Image: "nginx", ImageID: "sha256:nginx"},
+	}
+	removable, inUse := RemovableImages(images, containers)
+	if len(removable) != 1 || removable[0].ID != "sha256:redis" {
+		t.Fatalf("expected redis to be removable, got %v", removable)
+	}
+	if len(inUse) != 1 || inUse[0].ID != "sha256:nginx" {
+		t.Fatalf("expected nginx to be in use, got %v", inUse)
+	}
+}
+
+func TestTagGCCandidates(t *testing.T) {
+	images := []Image{
+		{ID: "1", Created: 100, RepoTags: []string{"app:v1"}},
+		{ID: "2", Created: 200, RepoTags: []string{"app:v2"}},
+		{ID: "3", Created: 300, RepoTags: []string{"app:v3", "app:latest"}},
+		{ID: "4", Created: 150, RepoTags: []string{"registry:5000/tool:old"}},
+		{ID: "5", Created: 250, RepoTags: []string{"registry:5000/tool:new"}},
+		{ID: "6", Created: 50, RepoTags: []string{"<none>:<none>"}},
+	}
+	expected := []string{"app:v1", "app:v2"}
+	if candidates := TagGCCandidates(images, 2); !reflect.DeepEqual(candidates, expected) {
+		t.Fatalf("expected %v, got %v", expected, candidates)
+	}
+}
@@ -0,0 +1,21 @@
+package types
+
+import "github.com/hyperhq/hyper-api/types/container"
+
+// WithInit sets whether the container to create runs an init process,
+// such as tini, as PID 1 to reap zombies and forward signals.
+func WithInit(cfg *ContainerCreateConfig, enabled bool) {
+	if cfg.HostConfig == nil {
+		cfg.HostConfig = &container.HostConfig{}
+	}
+	cfg.HostConfig.Init = &enabled
+}
+
+// HasInit returns whether the container was explicitly created with an
+// init process.
+func (c *ContainerJSON) HasInit() bool {
+	if c.ContainerJSONBase == nil || c.HostConfig == nil || c.HostConfig.Init == nil {
+		return false
+	}
+	return *c.HostConfig.Init
+}
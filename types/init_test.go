@@ -0,0 +1,35 @@
+package types
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestWithInit(t *testing.T) {
+	cfg := &ContainerCreateConfig{}
+	WithInit(cfg, true)
+	if cfg.HostConfig == nil || cfg.HostConfig.Init == nil || !*cfg.HostConfig.Init {
+		t.Fatalf("expected init to be enabled, got %+v", cfg.HostConfig)
+	}
+
+	b, err := json.Marshal(cfg.HostConfig)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var inspect ContainerJSON
+	if err := json.Unmarshal([]byte(`{"Id":"abc","HostConfig":`+string(b)+`}`), &inspect); err != nil {
+		t.Fatal(err)
+	}
+	if !inspect.HasInit() {
+		t.Fatal("expected the inspected container to have an init process")
+	}
+
+	WithInit(cfg, false)
+	inspect.HostConfig = cfg.HostConfig
+	if inspect.HasInit() {
+		t.Fatal("expected the inspected container not to have an init process")
+	}
+	if (&ContainerJSON{}).HasInit() {
+		t.Fatal("expected a container without host config not to have an init process")
+	}
+}
@@ -0,0 +1,54 @@
+package types
+
+import (
+	"fmt"
+	"time"
+)
+
+// IsTTY indicates whether the container was created with a TTY attached.
+func (c *ContainerJSON) IsTTY() bool {
+	return c.Config != nil && c.Config.Tty
+}
+
+// StreamIsMultiplexed indicates whether the attach and logs streams of the
+// container multiplex stdout and stderr, which is the case when it has no
+// TTY. Multiplexed streams must be split with stdcopy before use.
+func StreamIsMultiplexed(c *ContainerJSON) bool {
+	return !c.IsTTY()
+}
+
+// LogDriver returns the name of the logging driver of the container, as
+// set in HostConfig.LogConfig.Type.
+func (c *ContainerJSON) LogDriver() string {
+	if c.ContainerJSONBase == nil || c.HostConfig == nil {
+		return ""
+	}
+	return c.HostConfig.LogConfig.Type
+}
+
+// IsJSONFileLogging indicates whether the container logs with the
+// json-file driver, in which case LogPath points to its log file.
+func (c *ContainerJSON) IsJSONFileLogging() bool {
+	return c.LogDriver() == "json-file"
+}
+
+// RanShorterThan indicates whether the container ran and exited in less
+// than d, based on State.StartedAt and State.FinishedAt. Containers that are
+// still running or have never finished are reported as false.
+func (c *ContainerJSON) RanShorterThan(d time.Duration) (bool, error) {
+	if c.ContainerJSONBase == nil || c.State == nil || c.State.Running {
+		return false, nil
+	}
+	started, err := time.Parse(time.RFC3339Nano, c.State.StartedAt)
+	if err != nil {
+		return false, fmt.Errorf("invalid StartedAt %q: %v", c.State.StartedAt, err)
+	}
+	finished, err := time.Parse(time.RFC3339Nano, c.State.FinishedAt)
+	if err != nil {
+		return false, fmt.Errorf("invalid FinishedAt %q: %v", c.State.FinishedAt, err)
+	}
+	if started.IsZero() || finished.IsZero() || finished.Before(started) {
+		return false, nil
+	}
+	return finished.Sub(started) < d, nil
+}
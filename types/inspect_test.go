@@ -0,0 +1,95 @@
+package types
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/hyperhq/hyper-api/types/container"
+)
+
+func TestStreamIsMultiplexed(t *testing.T) {
+	tty := &ContainerJSON{Config: &container.Config{Tty: true}}
+	if !tty.IsTTY() || StreamIsMultiplexed(tty) {
+		t.Fatal("expected a TTY container not to be multiplexed")
+	}
+	noTTY := &ContainerJSON{Config: &container.Config{}}
+	if noTTY.IsTTY() || !StreamIsMultiplexed(noTTY) {
+		t.Fatal("expected a container without TTY to be multiplexed")
+	}
+	if (&ContainerJSON{}).IsTTY() {
+		t.Fatal("expected a container without config not to have a TTY")
+	}
+}
+
+func TestLogDriver(t *testing.T) {
+	body := `{
+		"Id": "ed3d5c3a9e11",
+		"LogPath": "/var/lib/docker/containers/ed3d5c3a9e11/ed3d5c3a9e11-json.log",
+		"HostConfig": {"LogConfig": {"Type": "json-file", "Config": {"max-size": "10m"}}}
+	}`
+	var c ContainerJSON
+	if err := json.Unmarshal([]byte(body), &c); err != nil {
+		t.Fatal(err)
+	}
+	if driver := c.LogDriver(); driver != "json-file" {
+		t.Fatalf("expected json-file, got %q", driver)
+	}
+	if !c.IsJSONFileLogging() {
+		t.Fatal("expected json-file logging")
+	}
+
+	c.HostConfig.LogConfig.Type = "syslog"
+	if c.IsJSONFileLogging() {
+		t.Fatal("expected syslog not to be json-file logging")
+	}
+	if driver := (&ContainerJSON{}).LogDriver(); driver != "" {
+		t.Fatalf("expected no driver, got %q", driver)
+	}
+}
+
+func TestRanShorterThan(t *testing.T) {
+	testCases := []struct {
+		state         ContainerState
+		expected      bool
+		expectedError bool
+	}{
+		{
+			state:    ContainerState{StartedAt: "2017-01-02T15:04:05.123456789Z", FinishedAt: "2017-01-02T15:04:07.123456789Z"},
+			expected: true,
+		},
+		{
+			state:    ContainerState{StartedAt: "2017-01-02T15:04:05Z", FinishedAt: "2017-01-02T15:05:05Z"},
+			expected: false,
+		},
+		{
+			state:    ContainerState{Running: true, StartedAt: "2017-01-02T15:04:05Z", FinishedAt: "0001-01-01T00:00:00Z"},
+			expected: false,
+		},
+		{
+			state:    ContainerState{StartedAt: "0001-01-01T00:00:00Z", FinishedAt: "0001-01-01T00:00:00Z"},
+			expected: false,
+		},
+		{
+			state:         ContainerState{StartedAt: "yesterday", FinishedAt: "2017-01-02T15:04:05Z"},
+			expectedError: true,
+		},
+	}
+	for _, c := range testCases {
+		state := c.state
+		container := &ContainerJSON{ContainerJSONBase: &ContainerJSONBase{State: &state}}
+		actual, err := container.RanShorterThan(10 * time.Second)
+		if c.expectedError {
+			if err == nil {
+				t.Fatalf("%+v: expected an error", c.state)
+			}
+			continue
+		}
+		if err != nil {
+			t.Fatalf("%+v: unexpected error: %v", c.state, err)
+		}
+		if actual != c.expected {
+			t.Fatalf("%+v: expected %v, got %v", c.state, c.expected, actual)
+		}
+	}
+}
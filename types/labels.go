@@ -0,0 +1,123 @@
+package types
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/hyperhq/hyper-api/types/container"
+)
+
+// SystemLabelPrefixes lists the label namespaces reserved for Hyper.sh and
+// Docker. Labels under these prefixes are hidden by Container.UserLabels.
+var SystemLabelPrefixes = []string{"sh.hyper.", "com.docker."}
+
+// FilterLabels returns a copy of labels without the keys that start with
+// any of hidePrefixes.
+func FilterLabels(labels map[string]string, hidePrefixes []string) map[string]string {
+	filtered := make(map[string]string, len(labels))
+	for k, v := range labels {
+		if hasAnyPrefix(k, hidePrefixes) {
+			continue
+		}
+		filtered[k] = v
+	}
+	return filtered
+}
+
+// UserLabels returns the labels of the container that were set by the
+// user, leaving out those under SystemLabelPrefixes.
+func (c Container) UserLabels() map[string]string {
+	return FilterLabels(c.Labels, SystemLabelPrefixes)
+}
+
+// PatchLabels sets the labels of patch on the container to create, then
+// deletes the labels listed in remove, which therefore take precedence.
+func PatchLabels(cfg *ContainerCreateConfig, patch map[string]string, remove []string) {
+	if cfg.Config == nil {
+		cfg.Config = &container.Config{}
+	}
+	if cfg.Config.Labels == nil {
+		cfg.Config.Labels = make(map[string]string, len(patch))
+	}
+	for k, v := range patch {
+		cfg.Config.Labels[k] = v
+	}
+	for _, k := range remove {
+		delete(cfg.Config.Labels, k)
+	}
+}
+
+func hasAnyPrefix(s string, prefixes []string) bool {
+	for _, p := range prefixes {
+		if strings.HasPrefix(s, p) {
+			return true
+		}
+	}
+	return false
+}
+
+// labelOperator is the comparison of a LabelSelector expression.
+type labelOperator int
+
+const (
+	labelExists labelOperator = iota
+	labelEquals
+	labelNotEquals
+)
+
+// labelRequirement is a single parsed LabelSelector expression.
+type labelRequirement struct {
+	key   string
+	op    labelOperator
+	value string
+}
+
+// LabelSelector matches labels against a list of expressions, which must
+// all hold: "key" requires the label to be set, "key=value" requires it to
+// have the value and "key!=value" requires it not to, which includes the
+// label being unset.
+type LabelSelector struct {
+	requirements []labelRequirement
+}
+
+// ParseLabelSelector parses the expressions of a LabelSelector. An
+// expression without a key, such as "=value", is an error.
+func ParseLabelSelector(exprs []string) (LabelSelector, error) {
+	var sel LabelSelector
+	for _, expr := range exprs {
+		req := labelRequirement{key: expr}
+		if i := strings.Index(expr, "!="); i >= 0 {
+			req = labelRequirement{key: expr[:i], op: labelNotEquals, value: expr[i+2:]}
+		} else if i := strings.Index(expr, "="); i >= 0 {
+			req = labelRequirement{key: expr[:i], op: labelEquals, value: expr[i+1:]}
+		}
+		if req.key == "" {
+			return LabelSelector{}, fmt.Errorf("invalid label selector %q: missing key", expr)
+		}
+		sel.requirements = append(sel.requirements, req)
+	}
+	return sel, nil
+}
+
+// Matches indicates whether labels satisfy all the expressions of the
+// selector. An empty selector matches any labels.
+func (s LabelSelector) Matches(labels map[string]string) bool {
+	for _, req := range s.requirements {
+		v, ok := labels[req.key]
+		switch req.op {
+		case labelExists:
+			if !ok {
+				return false
+			}
+		case labelEquals:
+			if !ok || v != req.value {
+				return false
+			}
+		case labelNotEquals:
+			if ok && v == req.value {
+				return false
+			}
+		}
+	}
+	return true
+}
@@ -0,0 +1,86 @@
+package types
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestUserLabels(t *testing.T) {
+	c := Container{
+		Labels: map[string]string{
+			"sh.hyper.instancetype":      "s4",
+			"com.docker.compose.project": "blog",
+			"app":                        "web",
+			"owner":                      "ops",
+		},
+	}
+	expected := map[string]string{"app": "web", "owner": "ops"}
+	if actual := c.UserLabels(); !reflect.DeepEqual(actual, expected) {
+		t.Fatalf("expected %v, got %v", expected, actual)
+	}
+}
+
+func TestFilterLabels(t *testing.T) {
+	labels := map[string]string{"a.b": "1", "a.c": "2", "b": "3"}
+	expected := map[string]string{"b": "3"}
+	if actual := FilterLabels(labels, []string{"a."}); !reflect.DeepEqual(actual, expected) {
+		t.Fatalf("expected %v, got %v", expected, actual)
+	}
+	if actual := FilterLabels(labels, nil); !reflect.DeepEqual(actual, labels) {
+		t.Fatalf("expected all labels to be kept, got %v", actual)
+	}
+}
+
+func TestPatchLabels(t *testing.T) {
+	cfg := &ContainerCreateConfig{}
+	PatchLabels(cfg, map[string]string{"app": "web", "tier": "front"}, nil)
+	if expected := map[string]string{"app": "web", "tier": "front"}; !reflect.DeepEqual(cfg.Config.Labels, expected) {
+		t.Fatalf("expected %v, got %v", expected, cfg.Config.Labels)
+	}
+
+	PatchLabels(cfg, map[string]string{"tier": "back", "owner": "ops", "tmp": "1"}, []string{"app", "tmp", "missing"})
+	if expected := map[string]string{"tier": "back", "owner": "ops"}; !reflect.DeepEqual(cfg.Config.Labels, expected) {
+		t.Fatalf("expected %v, got %v", expected, cfg.Config.Labels)
+	}
+}
+
+func TestLabelSelector(t *testing.T) {
+	volumes := []*Volume{
+		{Name: "db-prod", Labels: map[string]string{"env": "prod", "tier": "db"}},
+		{Name: "db-dev", Labels: map[string]string{"env": "dev", "tier": "db"}},
+		{Name: "cache", Labels: map[string]string{"env": "prod"}},
+		{Name: "scratch"},
+	}
+	testCases := []struct {
+		exprs    []string
+		expected []string
+	}{
+		{[]string{"env=prod"}, []string{"db-prod", "cache"}},
+		{[]string{"tier"}, []string{"db-prod", "db-dev"}},
+		{[]string{"env!=prod"}, []string{"db-dev", "scratch"}},
+		{[]string{"env=prod", "tier!=db"}, []string{"cache"}},
+		{[]string{"env="}, nil},
+		{nil, []string{"db-prod", "db-dev", "cache", "scratch"}},
+	}
+	for _, c := range testCases {
+		sel, err := ParseLabelSelector(c.exprs)
+		if err != nil {
+			t.Fatalf("%q: %v", c.exprs, err)
+		}
+		var names []string
+		for _, v := range volumes {
+			if sel.Matches(v.Labels) {
+				names = append(names, v.Name)
+			}
+		}
+		if !reflect.DeepEqual(names, c.expected) {
+			t.Fatalf("%q: expected %v, got %v", c.exprs, c.expected, names)
+		}
+	}
+
+	for _, expr := range []string{"=value", "!=value", ""} {
+		if _, err := ParseLabelSelector([]string{expr}); err == nil {
+			t.Fatalf("expected an error for %q", expr)
+		}
+	}
+}
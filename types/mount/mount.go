@@ -1,5 +1,7 @@
 package mount
 
+import "fmt"
+
 // Type represents the type of a mount.
 type Type string
 
@@ -39,9 +41,53 @@ const (
 	PropagationSlave Propagation = "slave"
 )
 
+// Consistency represents the consistency requirements of a bind mount.
+// It is a hint only honoured on platforms with a shared file system
+// between host and container, such as Docker for Mac.
+type Consistency string
+
+const (
+	// ConsistencyDefault DEFAULT
+	ConsistencyDefault Consistency = "default"
+	// ConsistencyFull CONSISTENT
+	ConsistencyFull Consistency = "consistent"
+	// ConsistencyCached CACHED
+	ConsistencyCached Consistency = "cached"
+	// ConsistencyDelegated DELEGATED
+	ConsistencyDelegated Consistency = "delegated"
+)
+
+// ValidateConsistency checks that c is a known consistency hint.
+// An empty value is accepted and means the default.
+func ValidateConsistency(c string) error {
+	switch Consistency(c) {
+	case "", ConsistencyDefault, ConsistencyFull, ConsistencyCached, ConsistencyDelegated:
+		return nil
+	}
+	return fmt.Errorf("invalid mount consistency: %q", c)
+}
+
+// ValidateMountPropagationFor checks that a propagation mode set on m is
+// known and that m is a bind mount, the only type supporting propagation.
+func ValidateMountPropagationFor(m Mount) error {
+	if m.BindOptions == nil || m.BindOptions.Propagation == "" {
+		return nil
+	}
+	switch p := m.BindOptions.Propagation; p {
+	case PropagationRPrivate, PropagationPrivate, PropagationRShared, PropagationShared, PropagationRSlave, PropagationSlave:
+		if m.Type != TypeBind {
+			return fmt.Errorf("invalid mount: propagation %q is only supported for bind mounts", p)
+		}
+		return nil
+	default:
+		return fmt.Errorf("invalid mount propagation: %q", p)
+	}
+}
+
 // BindOptions defines options specific to mounts of type "bind".
 type BindOptions struct {
 	Propagation Propagation `json:",omitempty"`
+	Consistency Consistency `json:",omitempty"`
 }
 
 // VolumeOptions represents the options for a mount of type volume.
@@ -56,3 +102,35 @@ type Driver struct {
 	Name    string            `json:",omitempty"`
 	Options map[string]string `json:",omitempty"`
 }
+
+// Validate checks that the mount is well formed before it is sent to the
+// daemon.
+func (m Mount) Validate() error {
+	if m.Target == "" {
+		return fmt.Errorf("invalid mount: target must not be empty")
+	}
+	if err := ValidateMountPropagationFor(m); err != nil {
+		return err
+	}
+	switch m.Type {
+	case TypeBind:
+		if m.Source == "" {
+			return fmt.Errorf("invalid mount: source must not be empty for bind mounts")
+		}
+		if m.VolumeOptions != nil {
+			return fmt.Errorf("invalid mount: volume options are not allowed for bind mounts")
+		}
+	case TypeVolume:
+		if m.BindOptions != nil {
+			return fmt.Errorf("invalid mount: bind options are not allowed for volume mounts")
+		}
+	default:
+		return fmt.Errorf("invalid mount type: %q", m.Type)
+	}
+	if m.BindOptions != nil {
+		if err := ValidateConsistency(string(m.BindOptions.Consistency)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,73 @@
+package mount
+
+import "testing"
+
+func TestValidateConsistency(t *testing.T) {
+	for _, c := range []string{"", "default", "consistent", "cached", "delegated"} {
+		if err := ValidateConsistency(c); err != nil {
+			t.Fatalf("expected %q to be valid, got %v", c, err)
+		}
+	}
+	if err := ValidateConsistency("fast"); err == nil {
+		t.Fatal("expected an error for consistency \"fast\"")
+	}
+}
+
+func TestMountValidate(t *testing.T) {
+	testCases := []struct {
+		mount         Mount
+		expectedError bool
+	}{
+		{
+			mount: Mount{Type: TypeBind, Source: "/src", Target: "/dst", BindOptions: &BindOptions{Consistency: ConsistencyCached}},
+		},
+		{
+			mount:         Mount{Type: TypeBind, Source: "/src", Target: "/dst", BindOptions: &BindOptions{Consistency: "fast"}},
+			expectedError: true,
+		},
+		{
+			mount: Mount{Type: TypeVolume, Source: "data", Target: "/data"},
+		},
+		{
+			mount:         Mount{Type: TypeVolume, Source: "data"},
+			expectedError: true,
+		},
+		{
+			mount:         Mount{Type: TypeBind, Target: "/dst"},
+			expectedError: true,
+		},
+		{
+			mount:         Mount{Type: "tmpfs", Target: "/tmp"},
+			expectedError: true,
+		},
+	}
+	for _, c := range testCases {
+		err := c.mount.Validate()
+		if err != nil && !c.expectedError {
+			t.Fatalf("unexpected error for %+v: %v", c.mount, err)
+		}
+		if err == nil && c.expectedError {
+			t.Fatalf("expected an error for %+v", c.mount)
+		}
+	}
+}
+
+func TestValidateMountPropagationFor(t *testing.T) {
+	bind := Mount{Type: TypeBind, Source: "/src", Target: "/dst", BindOptions: &BindOptions{Propagation: PropagationRShared}}
+	if err := ValidateMountPropagationFor(bind); err != nil {
+		t.Fatalf("expected rshared to be valid on a bind mount, got %v", err)
+	}
+
+	volume := Mount{Type: TypeVolume, Source: "data", Target: "/data", BindOptions: &BindOptions{Propagation: PropagationRShared}}
+	if err := ValidateMountPropagationFor(volume); err == nil {
+		t.Fatal("expected an error for rshared on a volume mount")
+	}
+	if err := volume.Validate(); err == nil {
+		t.Fatal("expected Validate to reject rshared on a volume mount")
+	}
+
+	bind.BindOptions.Propagation = "everywhere"
+	if err := ValidateMountPropagationFor(bind); err == nil {
+		t.Fatal("expected an error for an unknown propagation")
+	}
+}
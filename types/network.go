@@ -0,0 +1,276 @@
+package types
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"net"
+	"sort"
+	"strings"
+
+	"github.com/hyperhq/hyper-api/types/filters"
+)
+
+// MemberIDs returns the IDs of the containers connected to the network,
+// sorted.
+func (n NetworkResource) MemberIDs() []string {
+	ids := make([]string, 0, len(n.Containers))
+	for id := range n.Containers {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	return ids
+}
+
+// MemberCount returns the number of containers connected to the network.
+func (n NetworkResource) MemberCount() int {
+	return len(n.Containers)
+}
+
+// HasMember indicates whether the container with the given ID is connected
+// to the network.
+func (n NetworkResource) HasMember(id string) bool {
+	_, ok := n.Containers[id]
+	return ok
+}
+
+// ConnectivityMatrix reports which containers can reach each other because
+// they are connected to at least one common network, according to the
+// keys of NetworkSettings.Networks. The result is keyed by container ID and
+// matrix[a][b] is true when a and b share a network. Every container has a
+// row, and a container is not listed as reaching itself.
+func ConnectivityMatrix(containers []*ContainerJSON) map[string]map[string]bool {
+	matrix := make(map[string]map[string]bool, len(containers))
+	members := map[string][]string{}
+	for _, c := range containers {
+		if c.ContainerJSONBase == nil {
+			continue
+		}
+		matrix[c.ID] = map[string]bool{}
+		if c.NetworkSettings == nil {
+			continue
+		}
+		for name := range c.NetworkSettings.Networks {
+			members[name] = append(members[name], c.ID)
+		}
+	}
+
+	for _, ids := range members {
+		for _, a := range ids {
+			for _, b := range ids {
+				if a != b {
+					matrix[a][b] = true
+				}
+			}
+		}
+	}
+	return matrix
+}
+
+// primarySubnet returns the first valid subnet of the IPAM configuration of
+// the network, or nil if there is none.
+func (n NetworkResource) primarySubnet() *net.IPNet {
+	for _, c := range n.IPAM.Config {
+		if _, subnet, err := net.ParseCIDR(c.Subnet); err == nil {
+			return subnet
+		}
+	}
+	return nil
+}
+
+// RemainingAddresses returns the number of addresses of the primary subnet
+// of the network still free for new containers. The network and broadcast
+// addresses, the gateway and the containers already connected are taken out.
+// It returns an error if the network has no subnet or an IPv6 one.
+func (n NetworkResource) RemainingAddresses() (int, error) {
+	subnet := n.primarySubnet()
+	if subnet == nil {
+		return 0, fmt.Errorf("network %s has no subnet", n.Name)
+	}
+	if subnet.IP.To4() == nil {
+		return 0, fmt.Errorf("network %s: cannot count the addresses of IPv6 subnet %s", n.Name, subnet)
+	}
+	ones, bits := subnet.Mask.Size()
+	remaining := 1<<uint(bits-ones) - 2 - 1 - len(n.Containers)
+	if remaining < 0 {
+		remaining = 0
+	}
+	return remaining, nil
+}
+
+// OverlappingNetworks returns the pairs of IDs of the networks whose
+// primary subnets overlap, in the order the networks are given. Networks
+// without a subnet are ignored.
+func OverlappingNetworks(nets []NetworkResource) [][2]string {
+	subnets := make([]*net.IPNet, len(nets))
+	for i, n := range nets {
+		subnets[i] = n.primarySubnet()
+	}
+	var pairs [][2]string
+	for i := range nets {
+		for j := i + 1; j < len(nets); j++ {
+			a, b := subnets[i], subnets[j]
+			if a == nil || b == nil {
+				continue
+			}
+			if a.Contains(b.IP) || b.Contains(a.IP) {
+				pairs = append(pairs, [2]string{nets[i].ID, nets[j].ID})
+			}
+		}
+	}
+	return pairs
+}
+
+// NetworkGraphDOT writes a Graphviz DOT graph of the networks and of the
+// containers connected to them to w. Networks and containers are nodes,
+// labelled with their names, and each membership is an edge.
+func NetworkGraphDOT(w io.Writer, nets []NetworkResource) error {
+	var buf bytes.Buffer
+	buf.WriteString("graph networks {\n")
+	seen := map[string]bool{}
+	for _, n := range nets {
+		fmt.Fprintf(&buf, "\t%s [label=%s, shape=box];\n", dotID("network:"+n.ID), dotID(n.Name))
+		for _, id := range n.MemberIDs() {
+			if !seen[id] {
+				seen[id] = true
+				name := n.Containers[id].Name
+				if name == "" {
+					name = id
+				}
+				fmt.Fprintf(&buf, "\t%s [label=%s];\n", dotID("container:"+id), dotID(name))
+			}
+			fmt.Fprintf(&buf, "\t%s -- %s;\n", dotID("network:"+n.ID), dotID("container:"+id))
+		}
+	}
+	buf.WriteString("}\n")
+	_, err := buf.WriteTo(w)
+	return err
+}
+
+// dotID quotes s as a DOT identifier.
+func dotID(s string) string {
+	return `"` + strings.Replace(strings.Replace(s, `\`, `\\`, -1), `"`, `\"`, -1) + `"`
+}
+
+// Values of the "type" filter of the network list endpoint.
+const (
+	// NetworkTypeBuiltin selects the networks created by the daemon
+	NetworkTypeBuiltin = "builtin"
+	// NetworkTypeCustom selects the networks created by users
+	NetworkTypeCustom = "custom"
+)
+
+// NetworkFilter builds the filters of NetworkListOptions. Filters on the
+// same key match any of their values, while different keys must all match.
+type NetworkFilter struct {
+	args filters.Args
+}
+
+// NewNetworkFilter returns an empty NetworkFilter, matching all networks.
+func NewNetworkFilter() *NetworkFilter {
+	return &NetworkFilter{args: filters.NewArgs()}
+}
+
+// Driver selects the networks using the given driver, e.g. "overlay".
+func (f *NetworkFilter) Driver(driver string) *NetworkFilter {
+	f.args.Add("driver", driver)
+	return f
+}
+
+// Type selects either NetworkTypeBuiltin or NetworkTypeCustom networks.
+func (f *NetworkFilter) Type(typ string) *NetworkFilter {
+	f.args.Add("type", typ)
+	return f
+}
+
+// Scope selects the networks of the given scope, e.g. "local".
+func (f *NetworkFilter) Scope(scope string) *NetworkFilter {
+	f.args.Add("scope", scope)
+	return f
+}
+
+// Label selects the networks having the label key, with the given value
+// unless it is empty.
+func (f *NetworkFilter) Label(key, value string) *NetworkFilter {
+	if value != "" {
+		key += "=" + value
+	}
+	f.args.Add("label", key)
+	return f
+}
+
+// Options returns list options holding the filters built so far.
+func (f *NetworkFilter) Options() NetworkListOptions {
+	return NetworkListOptions{Filters: f.args}
+}
+
+// ContainsIP indicates whether ip falls inside any of the subnets of the
+// IPAM configuration of the network. It returns an error if ip or one of
+// the subnets cannot be parsed.
+func (n NetworkResource) ContainsIP(ip string) (bool, error) {
+	addr := net.ParseIP(ip)
+	if addr == nil {
+		return false, fmt.Errorf("invalid IP address %q", ip)
+	}
+	for _, c := range n.IPAM.Config {
+		if c.Subnet == "" {
+			continue
+		}
+		_, subnet, err := net.ParseCIDR(c.Subnet)
+		if err != nil {
+			return false, fmt.Errorf("network %s: invalid subnet %q", n.Name, c.Subnet)
+		}
+		if subnet.Contains(addr) {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// Validate checks the static addresses requested for the container against
+// the network nr it is connected to. The addresses must fall inside a
+// subnet of the network and must not be used by another of its containers.
+func (c NetworkConnect) Validate(nr NetworkResource) error {
+	if c.EndpointConfig == nil || c.EndpointConfig.IPAMConfig == nil {
+		return nil
+	}
+	for _, ip := range []string{c.EndpointConfig.IPAMConfig.IPv4Address, c.EndpointConfig.IPAMConfig.IPv6Address} {
+		if ip == "" {
+			continue
+		}
+		ok, err := nr.ContainsIP(ip)
+		if err != nil {
+			return err
+		}
+		if !ok {
+			return fmt.Errorf("address %s is outside the subnets of network %s", ip, nr.Name)
+		}
+		addr := net.ParseIP(ip)
+		for id, ep := range nr.Containers {
+			if id == c.Container || ep.Name == c.Container {
+				continue
+			}
+			if endpointHasIP(ep, addr) {
+				return fmt.Errorf("address %s is already in use by container %s on network %s", ip, ep.Name, nr.Name)
+			}
+		}
+	}
+	return nil
+}
+
+// endpointHasIP indicates whether ip is one of the addresses of ep, which
+// are given in CIDR notation.
+func endpointHasIP(ep EndpointResource, ip net.IP) bool {
+	for _, a := range []string{ep.IPv4Address, ep.IPv6Address} {
+		if a == "" {
+			continue
+		}
+		if i := strings.Index(a, "/"); i >= 0 {
+			a = a[:i]
+		}
+		if addr := net.ParseIP(a); addr != nil && addr.Equal(ip) {
+			return true
+		}
+	}
+	return false
+}
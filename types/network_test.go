@@ -0,0 +1,213 @@
+package types
+
+import (
+	"bytes"
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/hyperhq/hyper-api/types/network"
+)
+
+func TestNetworkResourceMembers(t *testing.T) {
+	body := `{
+		"Name": "backend",
+		"Id": "7d86d31b1478e7cca9ebed7e73aa0fdeec46c5ca29497431d3007d2d9e15ed99",
+		"Driver": "bridge",
+		"Containers": {
+			"ed3d5c3a9e11": {"Name": "web", "IPv4Address": "172.18.0.3/16"},
+			"39b69226f9d7": {"Name": "db", "IPv4Address": "172.18.0.2/16"}
+		}
+	}`
+	var n NetworkResource
+	if err := json.Unmarshal([]byte(body), &n); err != nil {
+		t.Fatal(err)
+	}
+	if ids := n.MemberIDs(); !reflect.DeepEqual(ids, []string{"39b69226f9d7", "ed3d5c3a9e11"}) {
+		t.Fatalf("unexpected member IDs: %v", ids)
+	}
+	if n.MemberCount() != 2 {
+		t.Fatalf("expected 2 members, got %d", n.MemberCount())
+	}
+	if !n.HasMember("ed3d5c3a9e11") || n.HasMember("ed3d") {
+		t.Fatal("HasMember should only match full container IDs")
+	}
+	if ids := (NetworkResource{}).MemberIDs(); len(ids) != 0 {
+		t.Fatalf("expected no members, got %v", ids)
+	}
+}
+
+func newNetworkedContainer(id string, networks ...string) *ContainerJSON {
+	settings := &NetworkSettings{Networks: map[string]*network.EndpointSettings{}}
+	for _, n := range networks {
+		settings.Networks[n] = &network.EndpointSettings{}
+	}
+	return &ContainerJSON{
+		ContainerJSONBase: &ContainerJSONBase{ID: id},
+		NetworkSettings:   settings,
+	}
+}
+
+func TestConnectivityMatrix(t *testing.T) {
+	matrix := ConnectivityMatrix([]*ContainerJSON{
+		newNetworkedContainer("web", "frontend", "backend"),
+		newNetworkedContainer("db", "backend"),
+		newNetworkedContainer("batch", "jobs"),
+	})
+	if !matrix["web"]["db"] || !matrix["db"]["web"] {
+		t.Fatal("expected web and db to reach each other over backend")
+	}
+	for _, id := range []string{"web", "db"} {
+		if matrix["batch"][id] || matrix[id]["batch"] {
+			t.Fatalf("expected batch to be isolated from %s", id)
+		}
+	}
+	if matrix["web"]["web"] {
+		t.Fatal("expected a container not to be listed as reaching itself")
+	}
+	if _, ok := matrix["batch"]; !ok {
+		t.Fatal("expected an isolated container to still have a row")
+	}
+}
+
+func TestOverlappingNetworks(t *testing.T) {
+	newNetwork := func(id, subnet string) NetworkResource {
+		n := NetworkResource{ID: id}
+		if subnet != "" {
+			n.IPAM.Config = []network.IPAMConfig{{Subnet: subnet}}
+		}
+		return n
+	}
+	nets := []NetworkResource{
+		newNetwork("a", "10.0.1.0/24"),
+		newNetwork("b", "10.0.2.0/24"),
+		newNetwork("c", "10.0.1.0/24"),
+		newNetwork("d", "10.0.0.0/16"),
+		newNetwork("e", "192.168.0.0/24"),
+		newNetwork("f", ""),
+	}
+	expected := [][2]string{{"a", "c"}, {"a", "d"}, {"b", "d"}, {"c", "d"}}
+	if pairs := OverlappingNetworks(nets); !reflect.DeepEqual(pairs, expected) {
+		t.Fatalf("expected %v, got %v", expected, pairs)
+	}
+	if pairs := OverlappingNetworks(nets[4:]); pairs != nil {
+		t.Fatalf("expected no overlap, got %v", pairs)
+	}
+}
+
+func TestNetworkGraphDOT(t *testing.T) {
+	nets := []NetworkResource{{
+		ID:   "net1",
+		Name: "backend",
+		Containers: map[string]EndpointResource{
+			"c2": {Name: "db"},
+			"c1": {Name: "web"},
+		},
+	}}
+	var buf bytes.Buffer
+	if err := NetworkGraphDOT(&buf, nets); err != nil {
+		t.Fatal(err)
+	}
+	dot := buf.String()
+	if !strings.HasPrefix(dot, "graph networks {\n") || !strings.HasSuffix(dot, "}\n") {
+		t.Fatalf("expected a DOT graph, got %s", dot)
+	}
+	for _, line := range []string{
+		`"network:net1" [label="backend", shape=box];`,
+		`"container:c1" [label="web"];`,
+		`"container:c2" [label="db"];`,
+		`"network:net1" -- "container:c1";`,
+		`"network:net1" -- "container:c2";`,
+	} {
+		if !strings.Contains(dot, "\t"+line+"\n") {
+			t.Fatalf("expected line %q in %s", line, dot)
+		}
+	}
+}
+
+func TestNetworkFilter(t *testing.T) {
+	options := NewNetworkFilter().Type(NetworkTypeCustom).Driver("overlay").Label("env", "prod").Label("team", "").Options()
+	expected := `{"driver":{"overlay":true},"label":{"env=prod":true,"team":true},"type":{"custom":true}}`
+	if filters := options.ToQuery().Get("filters"); filters != expected {
+		t.Fatalf("expected filters %s, got %s", expected, filters)
+	}
+	if query := NewNetworkFilter().Options().ToQuery(); len(query) != 0 {
+		t.Fatalf("expected an empty query, got %v", query)
+	}
+}
+
+func TestNetworkConnectValidate(t *testing.T) {
+	nr := NetworkResource{
+		Name: "backend",
+		IPAM: network.IPAM{Config: []network.IPAMConfig{{Subnet: "10.0.1.0/24"}, {Subnet: "fd00::/64"}}},
+		Containers: map[string]EndpointResource{
+			"abc": {Name: "db", IPv4Address: "10.0.1.5/24", IPv6Address: "fd00::5/64"},
+		},
+	}
+	for ip, expected := range map[string]bool{"10.0.1.7": true, "10.0.2.7": false, "fd00::7": true, "fd01::7": false} {
+		if ok, err := nr.ContainsIP(ip); err != nil || ok != expected {
+			t.Fatalf("expected %s to be contained: %v, got %v, %v", ip, expected, ok, err)
+		}
+	}
+	if _, err := nr.ContainsIP("10.0.1"); err == nil {
+		t.Fatal("expected an error for an invalid address")
+	}
+
+	connect := func(container, ipv4, ipv6 string) NetworkConnect {
+		return NetworkConnect{
+			Container:      container,
+			EndpointConfig: &network.EndpointSettings{IPAMConfig: &network.EndpointIPAMConfig{IPv4Address: ipv4, IPv6Address: ipv6}},
+		}
+	}
+	valid := []NetworkConnect{
+		{Container: "web"},
+		connect("web", "10.0.1.7", ""),
+		connect("web", "", "fd00::7"),
+		connect("db", "10.0.1.5", "fd00::5"),
+	}
+	for _, c := range valid {
+		if err := c.Validate(nr); err != nil {
+			t.Fatalf("expected %+v to be valid, got %v", c.EndpointConfig, err)
+		}
+	}
+	invalid := []NetworkConnect{
+		connect("web", "10.0.2.7", ""),
+		connect("web", "10.0.1.5", ""),
+		connect("web", "", "fd00::5"),
+		connect("web", "", "fd01::7"),
+		connect("web", "not-an-ip", ""),
+	}
+	for _, c := range invalid {
+		if err := c.Validate(nr); err == nil {
+			t.Fatalf("expected %+v to be invalid", c.EndpointConfig.IPAMConfig)
+		}
+	}
+}
+
+func TestRemainingAddresses(t *testing.T) {
+	nr := NetworkResource{
+		Name: "small",
+		IPAM: network.IPAM{Config: []network.IPAMConfig{{Subnet: "10.0.1.0/28", Gateway: "10.0.1.1"}}},
+		Containers: map[string]EndpointResource{
+			"a": {IPv4Address: "10.0.1.2/28"},
+			"b": {IPv4Address: "10.0.1.3/28"},
+			"c": {IPv4Address: "10.0.1.4/28"},
+		},
+	}
+	if remaining, err := nr.RemainingAddresses(); err != nil || remaining != 10 {
+		t.Fatalf("expected 10 remaining addresses, got %d, %v", remaining, err)
+	}
+
+	nr.IPAM.Config[0].Subnet = "10.0.1.0/30"
+	if remaining, err := nr.RemainingAddresses(); err != nil || remaining != 0 {
+		t.Fatalf("expected no remaining address, got %d, %v", remaining, err)
+	}
+	nr.IPAM.Config[0].Subnet = "fd00::/64"
+	if _, err := nr.RemainingAddresses(); err == nil {
+		t.Fatal("expected an error for an IPv6 subnet")
+	}
+	if _, err := (NetworkResource{}).RemainingAddresses(); err == nil {
+		t.Fatal("expected an error without a subnet")
+	}
+}
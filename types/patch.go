@@ -0,0 +1,60 @@
+package types
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// ApplyMergePatch applies the JSON merge patch of RFC 7386 to the JSON
+// encoding of base and returns the resulting config. Fields of the patch
+// replace those of base, null fields remove them and nested objects are
+// merged recursively. Arrays such as Config.Env are replaced as a whole.
+// base is not modified.
+func ApplyMergePatch(base *ContainerCreateConfig, patch []byte) (*ContainerCreateConfig, error) {
+	if base == nil {
+		base = &ContainerCreateConfig{}
+	}
+	doc, err := json.Marshal(base)
+	if err != nil {
+		return nil, err
+	}
+	var target, p interface{}
+	if err := json.Unmarshal(doc, &target); err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(patch, &p); err != nil {
+		return nil, fmt.Errorf("invalid merge patch: %v", err)
+	}
+	if _, ok := p.(map[string]interface{}); !ok {
+		return nil, fmt.Errorf("invalid merge patch: expected an object, got %s", patch)
+	}
+	if doc, err = json.Marshal(mergePatch(target, p)); err != nil {
+		return nil, err
+	}
+	patched := &ContainerCreateConfig{}
+	if err := json.Unmarshal(doc, patched); err != nil {
+		return nil, fmt.Errorf("invalid merge patch: %v", err)
+	}
+	return patched, nil
+}
+
+// mergePatch implements the MergePatch function of RFC 7386 on decoded
+// JSON values.
+func mergePatch(target, patch interface{}) interface{} {
+	p, ok := patch.(map[string]interface{})
+	if !ok {
+		return patch
+	}
+	t, ok := target.(map[string]interface{})
+	if !ok {
+		t = map[string]interface{}{}
+	}
+	for k, v := range p {
+		if v == nil {
+			delete(t, k)
+			continue
+		}
+		t[k] = mergePatch(t[k], v)
+	}
+	return t
+}
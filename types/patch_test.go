@@ -0,0 +1,53 @@
+package types
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/hyperhq/hyper-api/types/container"
+	"github.com/hyperhq/hyper-api/types/strslice"
+)
+
+func TestApplyMergePatch(t *testing.T) {
+	base := &ContainerCreateConfig{
+		Name: "web",
+		Config: &container.Config{
+			Image:  "nginx:1.10",
+			Cmd:    strslice.StrSlice{"nginx", "-g", "daemon off;"},
+			Labels: map[string]string{"app": "web", "tier": "front"},
+		},
+		HostConfig: &container.HostConfig{Binds: []string{"/data:/data"}},
+	}
+	patch := []byte(`{
+		"Config": {
+			"Image": "nginx:1.11",
+			"Env": ["NGINX_PORT=8080"],
+			"Labels": {"tier": null, "owner": "ops"}
+		}
+	}`)
+	patched, err := ApplyMergePatch(base, patch)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if patched.Name != "web" || patched.Config.Image != "nginx:1.11" {
+		t.Fatalf("unexpected name or image: %s, %s", patched.Name, patched.Config.Image)
+	}
+	if !reflect.DeepEqual(patched.Config.Env, []string{"NGINX_PORT=8080"}) || !reflect.DeepEqual(patched.Config.Cmd, base.Config.Cmd) {
+		t.Fatalf("unexpected command line: %q, %q", patched.Config.Env, patched.Config.Cmd)
+	}
+	if expected := map[string]string{"app": "web", "owner": "ops"}; !reflect.DeepEqual(patched.Config.Labels, expected) {
+		t.Fatalf("expected labels %v, got %v", expected, patched.Config.Labels)
+	}
+	if !reflect.DeepEqual(patched.HostConfig.Binds, []string{"/data:/data"}) {
+		t.Fatalf("expected the host config to be preserved, got %+v", patched.HostConfig)
+	}
+	if base.Config.Image != "nginx:1.10" || len(base.Config.Labels) != 2 {
+		t.Fatalf("expected base to be left untouched, got %+v", base.Config)
+	}
+
+	for _, patch := range []string{`[1]`, `{"Config": `, `{"Config": {"Image": 1}}`} {
+		if _, err := ApplyMergePatch(base, []byte(patch)); err == nil {
+			t.Fatalf("expected an error for patch %s", patch)
+		}
+	}
+}
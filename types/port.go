@@ -0,0 +1,69 @@
+package types
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// String returns the port in the form shown by `ps`, such as
+// "0.0.0.0:80->8080/tcp". The public side is left out when the port is
+// not published and the IP when it is empty.
+func (p Port) String() string {
+	private := fmt.Sprintf("%d/%s", p.PrivatePort, p.Type)
+	if p.PublicPort == 0 {
+		return private
+	}
+	public := strconv.Itoa(p.PublicPort)
+	if p.IP != "" {
+		public = p.IP + ":" + public
+	}
+	return public + "->" + private
+}
+
+// ParsePort parses a port in the form produced by Port.String. The type
+// defaults to "tcp" when omitted.
+func ParsePort(s string) (Port, error) {
+	var p Port
+	spec := s
+	p.Type = "tcp"
+	if i := strings.LastIndex(spec, "/"); i >= 0 {
+		p.Type = spec[i+1:]
+		spec = spec[:i]
+	}
+	if p.Type != "tcp" && p.Type != "udp" {
+		return Port{}, fmt.Errorf("invalid port %q: type must be tcp or udp", s)
+	}
+
+	private := spec
+	if i := strings.Index(spec, "->"); i >= 0 {
+		public := spec[:i]
+		private = spec[i+2:]
+		if j := strings.LastIndex(public, ":"); j >= 0 {
+			p.IP = public[:j]
+			public = public[j+1:]
+			if p.IP == "" {
+				return Port{}, fmt.Errorf("invalid port %q: empty IP", s)
+			}
+		}
+		n, err := parsePortNumber(public)
+		if err != nil {
+			return Port{}, fmt.Errorf("invalid port %q: %v", s, err)
+		}
+		p.PublicPort = n
+	}
+	n, err := parsePortNumber(private)
+	if err != nil {
+		return Port{}, fmt.Errorf("invalid port %q: %v", s, err)
+	}
+	p.PrivatePort = n
+	return p, nil
+}
+
+func parsePortNumber(s string) (int, error) {
+	n, err := strconv.Atoi(s)
+	if err != nil || n < 1 || n > 65535 {
+		return 0, fmt.Errorf("port number %q must be between 1 and 65535", s)
+	}
+	return n, nil
+}
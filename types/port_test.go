@@ -0,0 +1,43 @@
+package types
+
+import "testing"
+
+func TestPortString(t *testing.T) {
+	testCases := []struct {
+		port     Port
+		expected string
+	}{
+		{Port{IP: "0.0.0.0", PrivatePort: 8080, PublicPort: 80, Type: "tcp"}, "0.0.0.0:80->8080/tcp"},
+		{Port{PrivatePort: 8080, PublicPort: 80, Type: "tcp"}, "80->8080/tcp"},
+		{Port{PrivatePort: 53, Type: "udp"}, "53/udp"},
+		{Port{IP: "::", PrivatePort: 443, PublicPort: 8443, Type: "tcp"}, ":::8443->443/tcp"},
+	}
+	for _, c := range testCases {
+		if actual := c.port.String(); actual != c.expected {
+			t.Fatalf("expected %q, got %q", c.expected, actual)
+		}
+		parsed, err := ParsePort(c.expected)
+		if err != nil {
+			t.Fatalf("%q: unexpected error: %v", c.expected, err)
+		}
+		if parsed != c.port {
+			t.Fatalf("%q: expected %+v, got %+v", c.expected, c.port, parsed)
+		}
+	}
+}
+
+func TestParsePort(t *testing.T) {
+	p, err := ParsePort("8080")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if p != (Port{PrivatePort: 8080, Type: "tcp"}) {
+		t.Fatalf("unexpected port: %+v", p)
+	}
+
+	for _, s := range []string{"", "80/sctp", "0.0.0.0:0->80/tcp", "80->70000/tcp", "http/tcp", ":80->8080/tcp"} {
+		if _, err := ParsePort(s); err == nil {
+			t.Fatalf("expected an error for %q", s)
+		}
+	}
+}
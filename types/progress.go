@@ -0,0 +1,60 @@
+package types
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+)
+
+// ProgressDetail holds the number of bytes transferred so far for a layer
+// and its total size, which is 0 when unknown.
+type ProgressDetail struct {
+	Current int64 `json:"current,omitempty"`
+	Total   int64 `json:"total,omitempty"`
+}
+
+// Percent returns the share of the transfer done, between 0 and 100, or
+// -1 if the total size is unknown.
+func (p ProgressDetail) Percent() float64 {
+	if p.Total <= 0 {
+		return -1
+	}
+	if p.Current >= p.Total {
+		return 100
+	}
+	return float64(p.Current) * 100 / float64(p.Total)
+}
+
+// PullProgress is a frame of the JSON stream returned by the image pull
+// and push endpoints.
+type PullProgress struct {
+	Status         string         `json:"status,omitempty"`
+	ID             string         `json:"id,omitempty"`
+	Progress       string         `json:"progress,omitempty"`
+	ProgressDetail ProgressDetail `json:"progressDetail"`
+	Error          string         `json:"error,omitempty"`
+}
+
+// JSONProgressDecoder decodes the frames of the body returned by
+// ImagePull or ImagePush.
+type JSONProgressDecoder struct {
+	dec *json.Decoder
+}
+
+// NewJSONProgressDecoder returns a JSONProgressDecoder reading from r.
+func NewJSONProgressDecoder(r io.Reader) *JSONProgressDecoder {
+	return &JSONProgressDecoder{dec: json.NewDecoder(r)}
+}
+
+// Decode reads the next frame into p. An error frame is returned as an
+// error, and io.EOF is returned at the end of the stream.
+func (d *JSONProgressDecoder) Decode(p *PullProgress) error {
+	*p = PullProgress{}
+	if err := d.dec.Decode(p); err != nil {
+		return err
+	}
+	if p.Error != "" {
+		return errors.New(p.Error)
+	}
+	return nil
+}
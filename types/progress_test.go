@@ -0,0 +1,48 @@
+package types
+
+import (
+	"io"
+	"strings"
+	"testing"
+)
+
+func TestJSONProgressDecoder(t *testing.T) {
+	stream := `{"status":"Pulling from library/busybox","id":"latest"}
+{"status":"Downloading","progressDetail":{"current":1024,"total":4096},"progress":"[=>    ] 1.024 kB/4.096 kB","id":"8ddc19f16526"}
+{"status":"Pull complete","progressDetail":{},"id":"8ddc19f16526"}
+{"error":"unauthorized: authentication required","errorDetail":{"message":"unauthorized: authentication required"}}
+{"status":"never read"}
+`
+	dec := NewJSONProgressDecoder(strings.NewReader(stream))
+	var frames []PullProgress
+	var p PullProgress
+	var err error
+	for {
+		if err = dec.Decode(&p); err != nil {
+			break
+		}
+		frames = append(frames, p)
+	}
+	if err == io.EOF || err.Error() != "unauthorized: authentication required" {
+		t.Fatalf("expected the error frame as an error, got %v", err)
+	}
+	if len(frames) != 3 {
+		t.Fatalf("expected 3 frames before the error, got %d", len(frames))
+	}
+	if frames[0].ID != "latest" || frames[0].ProgressDetail != (ProgressDetail{}) {
+		t.Fatalf("unexpected first frame %+v", frames[0])
+	}
+	if detail := frames[1].ProgressDetail; detail.Current != 1024 || detail.Total != 4096 || detail.Percent() != 25 {
+		t.Fatalf("unexpected progress detail %+v", detail)
+	}
+	if frames[2].Status != "Pull complete" || frames[2].ProgressDetail.Percent() != -1 {
+		t.Fatalf("unexpected last frame %+v", frames[2])
+	}
+}
+
+func TestJSONProgressDecoderEOF(t *testing.T) {
+	var p PullProgress
+	if err := NewJSONProgressDecoder(strings.NewReader("")).Decode(&p); err != io.EOF {
+		t.Fatalf("expected io.EOF, got %v", err)
+	}
+}
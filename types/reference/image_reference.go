@@ -1,6 +1,9 @@
 package reference
 
 import (
+	"fmt"
+	"strings"
+
 	distreference "github.com/docker/distribution/reference"
 )
 
@@ -32,3 +35,103 @@ func GetTagFromNamedRef(ref distreference.Named) string {
 	}
 	return tag
 }
+
+// dockerHubHosts are the registry hosts of the Docker Hub.
+var dockerHubHosts = map[string]bool{
+	"docker.io":            true,
+	"index.docker.io":      true,
+	"registry-1.docker.io": true,
+}
+
+// RewriteForMirror replaces the registry host of ref with the mirror
+// host, preserving the repository, the tag and the digest. Docker Hub
+// references, including short ones such as "busybox", are expanded to
+// their full repository name, e.g. "mirror/library/busybox".
+func RewriteForMirror(ref, mirror string) (string, error) {
+	mirror = trimScheme(mirror)
+	if mirror == "" || strings.Contains(mirror, "/") {
+		return "", fmt.Errorf("invalid mirror %q: expected a registry host", mirror)
+	}
+	named, err := distreference.ParseNamed(ref)
+	if err != nil {
+		return "", err
+	}
+
+	host, repo := splitHost(named.Name())
+	if host == "" || dockerHubHosts[host] {
+		if !strings.Contains(repo, "/") {
+			repo = "library/" + repo
+		}
+	}
+	rewritten := mirror + "/" + repo
+	if tagged, ok := named.(distreference.Tagged); ok {
+		rewritten += ":" + tagged.Tag()
+	}
+	if digested, ok := named.(distreference.Digested); ok {
+		rewritten += "@" + digested.Digest().String()
+	}
+	return rewritten, nil
+}
+
+// CompleteReference returns the fully qualified form of ref, as pulled
+// from defaultRegistry when ref names no registry host. The "library"
+// namespace is added to single component names from that registry or the
+// Docker Hub, and the "latest" tag is added unless ref has a tag or a
+// digest. An empty defaultRegistry stands for the Docker Hub.
+func CompleteReference(ref, defaultRegistry string) (string, error) {
+	defaultRegistry = trimScheme(defaultRegistry)
+	if defaultRegistry == "" {
+		defaultRegistry = "docker.io"
+	}
+	if strings.Contains(defaultRegistry, "/") {
+		return "", fmt.Errorf("invalid registry %q: expected a registry host", defaultRegistry)
+	}
+	named, err := distreference.ParseNamed(ref)
+	if err != nil {
+		return "", err
+	}
+
+	host, repo := splitHost(named.Name())
+	if host == "" || dockerHubHosts[host] {
+		if !strings.Contains(repo, "/") {
+			repo = "library/" + repo
+		}
+	}
+	if host == "" {
+		host = defaultRegistry
+	}
+	completed := host + "/" + repo
+	tagged, isTagged := named.(distreference.Tagged)
+	digested, isDigested := named.(distreference.Digested)
+	if isTagged {
+		completed += ":" + tagged.Tag()
+	} else if !isDigested {
+		completed += ":latest"
+	}
+	if isDigested {
+		completed += "@" + digested.Digest().String()
+	}
+	return completed, nil
+}
+
+// trimScheme removes the URL scheme and the trailing slash of a registry
+// given as a URL.
+func trimScheme(registry string) string {
+	return strings.TrimSuffix(strings.TrimPrefix(strings.TrimPrefix(registry, "https://"), "http://"), "/")
+}
+
+// splitHost splits a repository name into its registry host, which is
+// empty if there is none, and the repository path. As in the docker CLI,
+// the first component is a host if it contains a "." or a ":" or if it
+// is "localhost".
+func splitHost(name string) (string, string) {
+	i := strings.Index(name, "/")
+	if i < 0 {
+		return "", name
+	}
+	host := name[:i]
+	if !strings.ContainsAny(host, ".:") && host != "localhost" {
+		return "", name
+	}
+	return host, name[i+1:]
+}
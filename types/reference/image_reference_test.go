@@ -70,3 +70,76 @@ func TestParse(t *testing.T) {
 		}
 	}
 }
+
+func TestRewriteForMirror(t *testing.T) {
+	testCases := []struct {
+		ref      string
+		mirror   string
+		expected string
+	}{
+		{"registry.hyper.sh/foo:bar", "mirror.example.com", "mirror.example.com/foo:bar"},
+		{"registry.hyper.sh/team/foo", "https://mirror.example.com:5000/", "mirror.example.com:5000/team/foo"},
+		{"busybox", "mirror.example.com", "mirror.example.com/library/busybox"},
+		{"busybox:1.26", "mirror.example.com", "mirror.example.com/library/busybox:1.26"},
+		{"docker.io/nginx", "mirror.example.com", "mirror.example.com/library/nginx"},
+		{"hyperhq/nfs-server:latest", "mirror.example.com", "mirror.example.com/hyperhq/nfs-server:latest"},
+		{"localhost/foo", "mirror.example.com", "mirror.example.com/foo"},
+		{
+			"registry.hyper.sh/foo@sha256:e4f6a84b52e1b6c01e4b4e3d5b9fd7c8a8e4fbc3c9d6e1b2bb9a3b0e6d1d9e7f",
+			"mirror.example.com",
+			"mirror.example.com/foo@sha256:e4f6a84b52e1b6c01e4b4e3d5b9fd7c8a8e4fbc3c9d6e1b2bb9a3b0e6d1d9e7f",
+		},
+	}
+	for _, c := range testCases {
+		rewritten, err := RewriteForMirror(c.ref, c.mirror)
+		if err != nil {
+			t.Fatalf("%s: %v", c.ref, err)
+		}
+		if rewritten != c.expected {
+			t.Fatalf("%s: expected %q, got %q", c.ref, c.expected, rewritten)
+		}
+	}
+
+	if _, err := RewriteForMirror("foo", "mirror.example.com/path"); err == nil {
+		t.Fatal("expected an error for a mirror with a path")
+	}
+	if _, err := RewriteForMirror("Foo:bar", "mirror.example.com"); err == nil {
+		t.Fatal("expected an error for an invalid reference")
+	}
+}
+
+func TestCompleteReference(t *testing.T) {
+	digest := "sha256:e4f6a84b52e1b6c01e4b4e3d5b9fd7c8a8e4fbc3c9d6e1b2bb9a3b0e6d1d9e7f"
+	testCases := []struct {
+		ref      string
+		registry string
+		expected string
+	}{
+		{"nginx", "", "docker.io/library/nginx:latest"},
+		{"nginx:1.11", "", "docker.io/library/nginx:1.11"},
+		{"user/app", "", "docker.io/user/app:latest"},
+		{"nginx", "https://registry.hyper.sh/", "registry.hyper.sh/library/nginx:latest"},
+		{"user/app", "registry.hyper.sh", "registry.hyper.sh/user/app:latest"},
+		{"docker.io/nginx", "registry.hyper.sh", "docker.io/library/nginx:latest"},
+		{"registry.example.com:5000/team/app:1.0", "registry.hyper.sh", "registry.example.com:5000/team/app:1.0"},
+		{"localhost/app", "", "localhost/app:latest"},
+		{"nginx@" + digest, "", "docker.io/library/nginx@" + digest},
+		{"registry.example.com/app:1.0@" + digest, "", "registry.example.com/app:1.0@" + digest},
+	}
+	for _, c := range testCases {
+		completed, err := CompleteReference(c.ref, c.registry)
+		if err != nil {
+			t.Fatalf("%s: %v", c.ref, err)
+		}
+		if completed != c.expected {
+			t.Fatalf("%s: expected %q, got %q", c.ref, c.expected, completed)
+		}
+	}
+
+	if _, err := CompleteReference("nginx", "registry.hyper.sh/path"); err == nil {
+		t.Fatal("expected an error for a registry with a path")
+	}
+	if _, err := CompleteReference("Nginx", ""); err == nil {
+		t.Fatal("expected an error for an invalid reference")
+	}
+}
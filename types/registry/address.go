@@ -0,0 +1,33 @@
+package registry
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+// DefaultRegistryHost is the registry used when no registry address is
+// given, which is the Docker Hub index.
+const DefaultRegistryHost = "index.docker.io"
+
+// NormalizeRegistryAddress reduces a registry address, such as the
+// ServerAddress of an AuthConfig, to a bare host[:port]. The scheme and
+// any path are stripped, so that "https://registry.hyper.sh/v1/" becomes
+// "registry.hyper.sh". An empty address normalizes to DefaultRegistryHost.
+func NormalizeRegistryAddress(addr string) (string, error) {
+	addr = strings.TrimSpace(addr)
+	if addr == "" {
+		return DefaultRegistryHost, nil
+	}
+	if !strings.Contains(addr, "://") {
+		addr = "https://" + addr
+	}
+	u, err := url.Parse(addr)
+	if err != nil {
+		return "", fmt.Errorf("invalid registry address %q: %v", addr, err)
+	}
+	if u.Host == "" || u.User != nil {
+		return "", fmt.Errorf("invalid registry address %q", addr)
+	}
+	return strings.ToLower(u.Host), nil
+}
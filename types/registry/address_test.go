@@ -0,0 +1,35 @@
+package registry
+
+import "testing"
+
+func TestNormalizeRegistryAddress(t *testing.T) {
+	testCases := []struct {
+		addr          string
+		expected      string
+		expectedError bool
+	}{
+		{addr: "", expected: DefaultRegistryHost},
+		{addr: "index.docker.io", expected: "index.docker.io"},
+		{addr: "https://index.docker.io/v1/", expected: "index.docker.io"},
+		{addr: "https://registry.hyper.sh/v1/", expected: "registry.hyper.sh"},
+		{addr: "http://localhost:5000", expected: "localhost:5000"},
+		{addr: "Registry.Example.com:443/v2", expected: "registry.example.com:443"},
+		{addr: "https:///v1/", expectedError: true},
+		{addr: "https://user@registry.example.com", expectedError: true},
+	}
+	for _, c := range testCases {
+		actual, err := NormalizeRegistryAddress(c.addr)
+		if c.expectedError {
+			if err == nil {
+				t.Fatalf("%q: expected an error, got %q", c.addr, actual)
+			}
+			continue
+		}
+		if err != nil {
+			t.Fatalf("%q: unexpected error: %v", c.addr, err)
+		}
+		if actual != c.expected {
+			t.Fatalf("%q: expected %q, got %q", c.addr, c.expected, actual)
+		}
+	}
+}
@@ -0,0 +1,52 @@
+package registry
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+)
+
+// AuthConfig contains authorization information for connecting to a Registry
+type AuthConfig struct {
+	Username string `json:"username,omitempty"`
+	Password string `json:"password,omitempty"`
+	Auth     string `json:"auth,omitempty"`
+
+	// Email is an optional value associated with the username.
+	// This field is deprecated and will be removed in a later
+	// version of docker.
+	Email string `json:"email,omitempty"`
+
+	ServerAddress string `json:"serveraddress,omitempty"`
+
+	// IdentityToken is used to authenticate the user and get
+	// an access token for the registry.
+	IdentityToken string `json:"identitytoken,omitempty"`
+
+	// RegistryToken is a bearer token to be sent to a registry
+	RegistryToken string `json:"registrytoken,omitempty"`
+}
+
+// EncodeAuthToBase64 encodes the credentials as the URL-safe base64 JSON
+// sent in the X-Registry-Auth header. An empty AuthConfig encodes to "{}".
+func EncodeAuthToBase64(auth AuthConfig) (string, error) {
+	buf, err := json.Marshal(auth)
+	if err != nil {
+		return "", err
+	}
+	return base64.URLEncoding.EncodeToString(buf), nil
+}
+
+// DecodeAuthConfig decodes the value of an X-Registry-Auth header as
+// produced by EncodeAuthToBase64.
+func DecodeAuthConfig(s string) (AuthConfig, error) {
+	var auth AuthConfig
+	buf, err := base64.URLEncoding.DecodeString(s)
+	if err != nil {
+		return auth, fmt.Errorf("invalid registry auth encoding: %v", err)
+	}
+	if err := json.Unmarshal(buf, &auth); err != nil {
+		return auth, fmt.Errorf("invalid registry auth: %v", err)
+	}
+	return auth, nil
+}
@@ -0,0 +1,41 @@
+package registry
+
+import (
+	"encoding/base64"
+	"testing"
+)
+
+func TestEncodeAuthToBase64(t *testing.T) {
+	auth := AuthConfig{Username: "user", Password: "p@ss>?", ServerAddress: "registry.hyper.sh"}
+	encoded, err := EncodeAuthToBase64(auth)
+	if err != nil {
+		t.Fatal(err)
+	}
+	decoded, err := DecodeAuthConfig(encoded)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if decoded != auth {
+		t.Fatalf("expected %+v, got %+v", auth, decoded)
+	}
+
+	encoded, err = EncodeAuthToBase64(AuthConfig{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if expected := base64.URLEncoding.EncodeToString([]byte("{}")); encoded != expected {
+		t.Fatalf("expected %q, got %q", expected, encoded)
+	}
+}
+
+func TestDecodeAuthConfigErrors(t *testing.T) {
+	invalids := []string{
+		"not base64!",
+		base64.URLEncoding.EncodeToString([]byte("not json")),
+	}
+	for _, s := range invalids {
+		if _, err := DecodeAuthConfig(s); err == nil {
+			t.Fatalf("%q: expected an error", s)
+		}
+	}
+}
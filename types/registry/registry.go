@@ -3,6 +3,7 @@ package registry
 import (
 	"encoding/json"
 	"net"
+	"sort"
 )
 
 // ServiceConfig stores daemon registry services configuration.
@@ -88,6 +89,12 @@ type SearchResult struct {
 	Description string `json:"description"`
 }
 
+// ImageSearchResult is a result of an image search. It has the fields and
+// the JSON encoding of SearchResult, which ImageSearch returns, and each
+// SearchResult converts to it with ImageSearchResult(r). It is not an alias
+// because type aliases need Go 1.9.
+type ImageSearchResult SearchResult
+
 // SearchResults lists a collection search results returned from a registry
 type SearchResults struct {
 	// Query contains the query string that generated the search results
@@ -97,3 +104,15 @@ type SearchResults struct {
 	// Results is a slice containing the actual results for the search
 	Results []SearchResult `json:"results"`
 }
+
+type byStars []SearchResult
+
+func (r byStars) Len() int           { return len(r) }
+func (r byStars) Swap(i, j int)      { r[i], r[j] = r[j], r[i] }
+func (r byStars) Less(i, j int) bool { return r[i].StarCount > r[j].StarCount }
+
+// SortByStars sorts results by decreasing star count, keeping the registry
+// order of results with as many stars.
+func SortByStars(results []SearchResult) {
+	sort.Stable(byStars(results))
+}
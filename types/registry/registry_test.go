@@ -0,0 +1,42 @@
+package registry
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestSortByStars(t *testing.T) {
+	var results []SearchResult
+	if err := json.Unmarshal([]byte(`[
+		{"star_count": 3, "is_official": false, "name": "a/nginx", "is_automated": true, "description": ""},
+		{"star_count": 9000, "is_official": true, "name": "nginx", "is_automated": false, "description": "Official build of Nginx."},
+		{"star_count": 3, "name": "b/nginx"},
+		{"star_count": 40, "name": "c/nginx"}
+	]`), &results); err != nil {
+		t.Fatal(err)
+	}
+	SortByStars(results)
+	expected := []string{"nginx", "c/nginx", "a/nginx", "b/nginx"}
+	for i, r := range results {
+		if r.Name != expected[i] {
+			t.Fatalf("expected %v, got %+v", expected, results)
+		}
+	}
+	if !results[0].IsOfficial || !results[2].IsAutomated {
+		t.Fatalf("unexpected flags: %+v", results)
+	}
+}
+
+func TestImageSearchResult(t *testing.T) {
+	var r ImageSearchResult
+	if err := json.Unmarshal([]byte(`{"star_count": 9000, "is_official": true, "name": "nginx", "is_automated": false, "description": "Official build of Nginx."}`), &r); err != nil {
+		t.Fatal(err)
+	}
+	expected := ImageSearchResult{StarCount: 9000, IsOfficial: true, Name: "nginx", Description: "Official build of Nginx."}
+	if r != expected {
+		t.Fatalf("expected %+v, got %+v", expected, r)
+	}
+	if SearchResult(r) != (SearchResult{StarCount: 9000, IsOfficial: true, Name: "nginx", Description: "Official build of Nginx."}) {
+		t.Fatalf("unexpected conversion: %+v", SearchResult(r))
+	}
+}
@@ -0,0 +1,85 @@
+package types
+
+import (
+	"fmt"
+	"regexp"
+
+	"github.com/docker/go-units"
+	"github.com/hyperhq/hyper-api/types/container"
+)
+
+// affinityRegexp matches affinity expressions in the Swarm form
+// "affinity:<key><op><value>", where op is "==" or "!=".
+var affinityRegexp = regexp.MustCompile(`^affinity:([a-zA-Z0-9_.-]+)(==|!=)(\S+)$`)
+
+// AddAffinity adds the affinity expression expr, such as
+// "affinity:container==db", to the environment of the container to create,
+// which is where the scheduler looks for it.
+func AddAffinity(cfg *ContainerCreateConfig, expr string) error {
+	if !affinityRegexp.MatchString(expr) {
+		return fmt.Errorf("invalid affinity expression %q: expected affinity:<key>==<value> or affinity:<key>!=<value>", expr)
+	}
+	if cfg.Config == nil {
+		cfg.Config = &container.Config{}
+	}
+	for _, e := range cfg.Config.Env {
+		if e == expr {
+			return nil
+		}
+	}
+	cfg.Config.Env = append(cfg.Config.Env, expr)
+	return nil
+}
+
+// ResourceRequest is the amount of memory and CPU a container asks for,
+// as used to place containers on hosts.
+type ResourceRequest struct {
+	MemoryBytes int64
+	NanoCPUs    int64
+}
+
+// ResourceRequest returns the resources requested by the container to
+// create. The CPU request is taken from NanoCPUs, or derived from the CFS
+// quota and period if NanoCPUs is not set.
+func (cfg ContainerCreateConfig) ResourceRequest() ResourceRequest {
+	if cfg.HostConfig == nil {
+		return ResourceRequest{}
+	}
+	r := cfg.HostConfig.Resources
+	req := ResourceRequest{MemoryBytes: r.Memory, NanoCPUs: r.NanoCPUs}
+	if req.NanoCPUs == 0 && r.CPUQuota > 0 && r.CPUPeriod > 0 {
+		req.NanoCPUs = r.CPUQuota * 1e9 / r.CPUPeriod
+	}
+	return req
+}
+
+// FitsIn indicates whether req can be satisfied by the free resources.
+func FitsIn(req ResourceRequest, free ResourceRequest) bool {
+	return req.MemoryBytes <= free.MemoryBytes && req.NanoCPUs <= free.NanoCPUs
+}
+
+// OvercommitWarnings sums the resources requested by the containers, see
+// ContainerCreateConfig.ResourceRequest, and returns a warning for each
+// resource whose total exceeds the capacity of the host. Containers without
+// a limit on a resource do not count towards its total.
+func OvercommitWarnings(containers []*ContainerJSON, hostMemory int64, hostNanoCPUs int64) []string {
+	var total ResourceRequest
+	for _, c := range containers {
+		if c == nil || c.ContainerJSONBase == nil {
+			continue
+		}
+		req := ContainerCreateConfig{HostConfig: c.HostConfig}.ResourceRequest()
+		total.MemoryBytes += req.MemoryBytes
+		total.NanoCPUs += req.NanoCPUs
+	}
+	var warnings []string
+	if total.MemoryBytes > hostMemory {
+		warnings = append(warnings, fmt.Sprintf("memory is overcommitted: containers request %s but the host has %s",
+			units.BytesSize(float64(total.MemoryBytes)), units.BytesSize(float64(hostMemory))))
+	}
+	if total.NanoCPUs > hostNanoCPUs {
+		warnings = append(warnings, fmt.Sprintf("CPU is overcommitted: containers request %.2f CPUs but the host has %.2f",
+			float64(total.NanoCPUs)/1e9, float64(hostNanoCPUs)/1e9))
+	}
+	return warnings
+}
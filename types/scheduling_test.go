@@ -0,0 +1,94 @@
+package types
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/hyperhq/hyper-api/types/container"
+)
+
+func TestAddAffinity(t *testing.T) {
+	var cfg ContainerCreateConfig
+	if err := AddAffinity(&cfg, "affinity:container==db"); err != nil {
+		t.Fatal(err)
+	}
+	if err := AddAffinity(&cfg, "affinity:image!=redis"); err != nil {
+		t.Fatal(err)
+	}
+	if err := AddAffinity(&cfg, "affinity:container==db"); err != nil {
+		t.Fatal(err)
+	}
+	expected := []string{"affinity:container==db", "affinity:image!=redis"}
+	if !reflect.DeepEqual(cfg.Config.Env, expected) {
+		t.Fatalf("expected %v, got %v", expected, cfg.Config.Env)
+	}
+
+	for _, expr := range []string{"container==db", "affinity:container=db", "affinity:==db", "affinity:container==", "affinity:container~=db"} {
+		if err := AddAffinity(&cfg, expr); err == nil {
+			t.Fatalf("expected an error for %q", expr)
+		}
+	}
+}
+
+func TestResourceRequest(t *testing.T) {
+	testCases := []struct {
+		resources container.Resources
+		expected  ResourceRequest
+	}{
+		{container.Resources{Memory: 512 << 20, NanoCPUs: 5e8}, ResourceRequest{MemoryBytes: 512 << 20, NanoCPUs: 5e8}},
+		{container.Resources{Memory: 1 << 30, CPUQuota: 150000, CPUPeriod: 100000}, ResourceRequest{MemoryBytes: 1 << 30, NanoCPUs: 15e8}},
+		{container.Resources{}, ResourceRequest{}},
+	}
+	for _, c := range testCases {
+		cfg := ContainerCreateConfig{HostConfig: &container.HostConfig{Resources: c.resources}}
+		if req := cfg.ResourceRequest(); req != c.expected {
+			t.Fatalf("expected %+v, got %+v", c.expected, req)
+		}
+	}
+	if req := (ContainerCreateConfig{}).ResourceRequest(); req != (ResourceRequest{}) {
+		t.Fatalf("expected an empty request, got %+v", req)
+	}
+}
+
+func TestFitsIn(t *testing.T) {
+	req := ResourceRequest{MemoryBytes: 512 << 20, NanoCPUs: 1e9}
+	if !FitsIn(req, ResourceRequest{MemoryBytes: 1 << 30, NanoCPUs: 2e9}) {
+		t.Fatal("expected the request to fit")
+	}
+	if !FitsIn(req, req) {
+		t.Fatal("expected the request to fit exactly")
+	}
+	if FitsIn(req, ResourceRequest{MemoryBytes: 256 << 20, NanoCPUs: 2e9}) {
+		t.Fatal("expected the request not to fit in memory")
+	}
+	if FitsIn(req, ResourceRequest{MemoryBytes: 1 << 30, NanoCPUs: 5e8}) {
+		t.Fatal("expected the request not to fit in CPU")
+	}
+}
+
+func TestOvercommitWarnings(t *testing.T) {
+	newContainer := func(memory, nanoCPUs int64) *ContainerJSON {
+		return &ContainerJSON{ContainerJSONBase: &ContainerJSONBase{
+			HostConfig: &container.HostConfig{Resources: container.Resources{Memory: memory, NanoCPUs: nanoCPUs}},
+		}}
+	}
+	containers := []*ContainerJSON{
+		newContainer(2<<30, 1e9),
+		newContainer(3<<30, 1e9),
+		newContainer(0, 0),
+		{},
+	}
+
+	warnings := OvercommitWarnings(containers, 4<<30, 4e9)
+	if len(warnings) != 1 || !strings.HasPrefix(warnings[0], "memory is overcommitted") {
+		t.Fatalf("expected a memory warning, got %v", warnings)
+	}
+	warnings = OvercommitWarnings(containers, 4<<30, 1e9)
+	if len(warnings) != 2 || !strings.HasPrefix(warnings[1], "CPU is overcommitted") {
+		t.Fatalf("expected memory and CPU warnings, got %v", warnings)
+	}
+	if warnings := OvercommitWarnings(containers, 8<<30, 4e9); warnings != nil {
+		t.Fatalf("expected no warnings, got %v", warnings)
+	}
+}
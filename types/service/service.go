@@ -0,0 +1,70 @@
+// Package service holds the types used to create and list Hyper.sh
+// services, which are load balanced sets of containers behind a single
+// service IP.
+package service
+
+import (
+	"fmt"
+
+	"github.com/hyperhq/hyper-api/types"
+)
+
+// ServiceCreate is the request to create a service.
+type ServiceCreate struct {
+	Name          string
+	Image         string
+	Replicas      int    // Replicas is the number of containers of the service
+	ServicePort   int    // ServicePort is the port the service IP listens on
+	ContainerPort int    // ContainerPort is the port the containers listen on
+	Protocol      string // Protocol is tcp, http or https
+	Labels        map[string]string
+	Env           []string
+}
+
+// Validate checks the request before it is sent. A name and an image are
+// required, the ports must be valid and the protocol, when set, must be
+// tcp, http or https.
+func (s ServiceCreate) Validate() error {
+	if s.Name == "" {
+		return fmt.Errorf("service name cannot be empty")
+	}
+	if s.Image == "" {
+		return fmt.Errorf("service %s: an image is required", s.Name)
+	}
+	if s.Replicas < 0 {
+		return fmt.Errorf("service %s: invalid replicas %d: must not be negative", s.Name, s.Replicas)
+	}
+	if s.ServicePort < 1 || s.ServicePort > 65535 {
+		return fmt.Errorf("service %s: invalid service port %d: must be between 1 and 65535", s.Name, s.ServicePort)
+	}
+	if s.ContainerPort < 1 || s.ContainerPort > 65535 {
+		return fmt.Errorf("service %s: invalid container port %d: must be between 1 and 65535", s.Name, s.ContainerPort)
+	}
+	switch s.Protocol {
+	case "", types.LBProtocolTCP, types.LBProtocolHTTP, types.LBProtocolHTTPS:
+	default:
+		return fmt.Errorf("service %s: invalid protocol %q: must be %s, %s or %s", s.Name, s.Protocol, types.LBProtocolTCP, types.LBProtocolHTTP, types.LBProtocolHTTPS)
+	}
+	return nil
+}
+
+// ServiceCreateResponse holds the service created by a ServiceCreate.
+type ServiceCreateResponse struct {
+	ID        string
+	ServiceIP string `json:"IP"`
+}
+
+// Service describes a service as listed or inspected.
+type Service struct {
+	ID            string
+	Name          string
+	Image         string
+	Replicas      int
+	ServicePort   int
+	ContainerPort int
+	Protocol      string
+	ServiceIP     string `json:"IP"`
+	Status        string
+	Containers    []string // Containers are the IDs of the containers of the service
+	Labels        map[string]string
+}
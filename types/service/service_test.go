@@ -0,0 +1,42 @@
+package service
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/hyperhq/hyper-api/types"
+)
+
+func TestServiceCreateValidate(t *testing.T) {
+	web := ServiceCreate{Name: "web", Image: "nginx", Replicas: 3, ServicePort: 80, ContainerPort: 8080, Protocol: types.LBProtocolHTTP}
+	if err := web.Validate(); err != nil {
+		t.Fatal(err)
+	}
+	testCases := []func(s *ServiceCreate){
+		func(s *ServiceCreate) { s.Name = "" },
+		func(s *ServiceCreate) { s.Image = "" },
+		func(s *ServiceCreate) { s.Replicas = -1 },
+		func(s *ServiceCreate) { s.ServicePort = 0 },
+		func(s *ServiceCreate) { s.ContainerPort = 70000 },
+		func(s *ServiceCreate) { s.ContainerPort = 0 },
+		func(s *ServiceCreate) { s.Protocol = "udp" },
+		func(s *ServiceCreate) { s.Protocol = types.LBProtocolHTTPSTERM },
+	}
+	for i, change := range testCases {
+		s := web
+		change(&s)
+		if err := s.Validate(); err == nil {
+			t.Fatalf("case %d: expected %+v to be invalid", i, s)
+		}
+	}
+}
+
+func TestServiceCreateResponse(t *testing.T) {
+	var resp ServiceCreateResponse
+	if err := json.Unmarshal([]byte(`{"ID":"a1b2","Name":"web","IP":"10.0.0.5"}`), &resp); err != nil {
+		t.Fatal(err)
+	}
+	if resp.ID != "a1b2" || resp.ServiceIP != "10.0.0.5" {
+		t.Fatalf("unexpected response: %+v", resp)
+	}
+}
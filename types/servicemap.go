@@ -0,0 +1,200 @@
+package types
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/docker/go-connections/nat"
+	"github.com/hyperhq/hyper-api/types/container"
+	"github.com/hyperhq/hyper-api/types/strslice"
+)
+
+// ServiceToCreateConfig converts a simplified compose-like service, as
+// decoded from YAML or JSON, to the config creating its container. The
+// supported keys are image, command, environment, ports, volumes and
+// labels; any other key is an error.
+func ServiceToCreateConfig(name string, svc map[string]interface{}) (*ContainerCreateConfig, error) {
+	cfg := &ContainerCreateConfig{
+		Name:       name,
+		Config:     &container.Config{},
+		HostConfig: &container.HostConfig{},
+	}
+	keys := make([]string, 0, len(svc))
+	for k := range svc {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		v := svc[k]
+		var err error
+		switch k {
+		case "image":
+			cfg.Config.Image, err = serviceString(v)
+		case "command":
+			var cmd []string
+			cmd, err = serviceCommand(v)
+			cfg.Config.Cmd = strslice.StrSlice(cmd)
+		case "environment":
+			cfg.Config.Env, err = serviceKeyValues(v)
+		case "labels":
+			var labels []string
+			labels, err = serviceKeyValues(v)
+			if err == nil && len(labels) > 0 {
+				cfg.Config.Labels = make(map[string]string, len(labels))
+				for _, l := range labels {
+					kv := strings.SplitN(l, "=", 2)
+					if len(kv) == 1 {
+						kv = append(kv, "")
+					}
+					cfg.Config.Labels[kv[0]] = kv[1]
+				}
+			}
+		case "ports":
+			var specs []string
+			specs, err = serviceStrings(v)
+			if err == nil {
+				cfg.Config.ExposedPorts, cfg.HostConfig.PortBindings, err = nat.ParsePortSpecs(specs)
+			}
+		case "volumes":
+			cfg.HostConfig.Binds, err = serviceStrings(v)
+		default:
+			return nil, fmt.Errorf("service %s: unknown key %q", name, k)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("service %s: invalid %s: %v", name, k, err)
+		}
+	}
+	if cfg.Config.Image == "" {
+		return nil, fmt.Errorf("service %s: an image is required", name)
+	}
+	return cfg, nil
+}
+
+func serviceString(v interface{}) (string, error) {
+	switch s := v.(type) {
+	case string:
+		return s, nil
+	case int, int64, float64, bool:
+		return fmt.Sprint(s), nil
+	}
+	return "", fmt.Errorf("expected a string, got %T", v)
+}
+
+func serviceStrings(v interface{}) ([]string, error) {
+	list, ok := v.([]interface{})
+	if !ok {
+		if s, ok := v.([]string); ok {
+			return s, nil
+		}
+		return nil, fmt.Errorf("expected a list, got %T", v)
+	}
+	strs := make([]string, len(list))
+	for i, e := range list {
+		s, err := serviceString(e)
+		if err != nil {
+			return nil, err
+		}
+		strs[i] = s
+	}
+	return strs, nil
+}
+
+// serviceCommand accepts a command either as a list or as a string split
+// into words the way a shell would, honouring quotes.
+func serviceCommand(v interface{}) ([]string, error) {
+	if s, ok := v.(string); ok {
+		cmd, err := splitShellWords(s)
+		if err != nil {
+			return nil, fmt.Errorf("invalid command %q: %v", s, err)
+		}
+		return cmd, nil
+	}
+	return serviceStrings(v)
+}
+
+// splitShellWords splits s into words like a POSIX shell without
+// expansions: words are separated by blanks, single quotes keep their
+// content as is, and a backslash escapes the next character, except within
+// double quotes where it only escapes ", \, $ and `.
+func splitShellWords(s string) ([]string, error) {
+	var (
+		words   []string
+		word    []rune
+		inWord  bool
+		quote   rune
+		escaped bool
+	)
+	for _, r := range s {
+		switch {
+		case escaped:
+			if quote == '"' && !strings.ContainsRune("\"\\$`", r) {
+				word = append(word, '\\')
+			}
+			word = append(word, r)
+			escaped = false
+		case r == '\\' && quote != '\'':
+			escaped, inWord = true, true
+		case quote != 0:
+			if r == quote {
+				quote = 0
+			} else {
+				word = append(word, r)
+			}
+		case r == '\'' || r == '"':
+			quote, inWord = r, true
+		case r == ' ' || r == '\t' || r == '\n':
+			if inWord {
+				words = append(words, string(word))
+				word, inWord = word[:0], false
+			}
+		default:
+			word = append(word, r)
+			inWord = true
+		}
+	}
+	if escaped {
+		return nil, fmt.Errorf("trailing backslash")
+	}
+	if quote != 0 {
+		return nil, fmt.Errorf("unterminated %c quote", quote)
+	}
+	if inWord {
+		words = append(words, string(word))
+	}
+	return words, nil
+}
+
+// serviceKeyValues accepts either a map or a list of "KEY=VALUE" entries.
+// The entries of a map are returned sorted.
+func serviceKeyValues(v interface{}) ([]string, error) {
+	var m map[string]interface{}
+	switch t := v.(type) {
+	case map[string]interface{}:
+		m = t
+	case map[interface{}]interface{}:
+		m = make(map[string]interface{}, len(t))
+		for k, e := range t {
+			m[fmt.Sprint(k)] = e
+		}
+	default:
+		list, err := serviceStrings(v)
+		if err != nil {
+			return nil, fmt.Errorf("expected a map or a list, got %T", v)
+		}
+		return list, nil
+	}
+	kvs := make([]string, 0, len(m))
+	for k, e := range m {
+		s := ""
+		if e != nil {
+			var err error
+			if s, err = serviceString(e); err != nil {
+				return nil, err
+			}
+		}
+		kvs = append(kvs, k+"="+s)
+	}
+	sort.Strings(kvs)
+	return kvs, nil
+}
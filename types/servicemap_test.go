@@ -0,0 +1,84 @@
+package types
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/docker/go-connections/nat"
+)
+
+func TestServiceToCreateConfig(t *testing.T) {
+	svc := map[string]interface{}{
+		"image":       "nginx:1.11",
+		"command":     "nginx -g 'daemon off;'",
+		"ports":       []interface{}{"8080:80", 443},
+		"environment": map[string]interface{}{"TZ": "UTC", "DEBUG": 1},
+		"volumes":     []interface{}{"data:/usr/share/nginx/html:ro"},
+		"labels":      []interface{}{"tier=front", "public"},
+	}
+	cfg, err := ServiceToCreateConfig("web", svc)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cfg.Name != "web" || cfg.Config.Image != "nginx:1.11" {
+		t.Fatalf("unexpected config: %+v", cfg.Config)
+	}
+	if expected := []string{"nginx", "-g", "daemon off;"}; !reflect.DeepEqual([]string(cfg.Config.Cmd), expected) {
+		t.Fatalf("expected command %v, got %v", expected, cfg.Config.Cmd)
+	}
+	if expected := []string{"DEBUG=1", "TZ=UTC"}; !reflect.DeepEqual(cfg.Config.Env, expected) {
+		t.Fatalf("expected env %v, got %v", expected, cfg.Config.Env)
+	}
+	if _, ok := cfg.Config.ExposedPorts["443/tcp"]; !ok || len(cfg.Config.ExposedPorts) != 2 {
+		t.Fatalf("unexpected exposed ports: %v", cfg.Config.ExposedPorts)
+	}
+	if expected := []nat.PortBinding{{HostPort: "8080"}}; !reflect.DeepEqual(cfg.HostConfig.PortBindings["80/tcp"], expected) {
+		t.Fatalf("expected bindings %v, got %v", expected, cfg.HostConfig.PortBindings["80/tcp"])
+	}
+	if expected := []string{"data:/usr/share/nginx/html:ro"}; !reflect.DeepEqual(cfg.HostConfig.Binds, expected) {
+		t.Fatalf("expected binds %v, got %v", expected, cfg.HostConfig.Binds)
+	}
+	if expected := map[string]string{"tier": "front", "public": ""}; !reflect.DeepEqual(cfg.Config.Labels, expected) {
+		t.Fatalf("expected labels %v, got %v", expected, cfg.Config.Labels)
+	}
+}
+
+func TestServiceToCreateConfigErrors(t *testing.T) {
+	invalids := []map[string]interface{}{
+		{"image": "nginx", "restart": "always"},
+		{"command": "nginx"},
+		{"image": "nginx", "ports": "80"},
+		{"image": "nginx", "ports": []interface{}{"80:http"}},
+		{"image": []interface{}{"nginx"}},
+		{"image": "nginx", "command": "sh -c 'echo hi"},
+	}
+	for _, svc := range invalids {
+		if _, err := ServiceToCreateConfig("web", svc); err == nil {
+			t.Fatalf("%v: expected an error", svc)
+		}
+	}
+}
+
+func TestSplitShellWords(t *testing.T) {
+	testCases := []struct {
+		in       string
+		expected []string
+	}{
+		{"", nil},
+		{"  nginx  ", []string{"nginx"}},
+		{`sh -c 'echo hi'`, []string{"sh", "-c", "echo hi"}},
+		{`echo "a \"b\" \c" ''`, []string{"echo", `a "b" \c`, ""}},
+		{`echo a\ b 'it'\''s'`, []string{"echo", "a b", "it's"}},
+	}
+	for _, c := range testCases {
+		words, err := splitShellWords(c.in)
+		if err != nil || !reflect.DeepEqual(words, c.expected) {
+			t.Fatalf("%s: expected %q, got %q (%v)", c.in, c.expected, words, err)
+		}
+	}
+	for _, in := range []string{`echo 'hi`, `echo "hi`, `echo \`} {
+		if _, err := splitShellWords(in); err == nil {
+			t.Fatalf("%s: expected an error", in)
+		}
+	}
+}
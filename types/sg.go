@@ -0,0 +1,216 @@
+package types
+
+import (
+	"fmt"
+	"net"
+	"sort"
+	"strings"
+)
+
+// Directions and protocols of security group rules.
+const (
+	RuleDirectionIngress = "ingress"
+	RuleDirectionEgress  = "egress"
+
+	RuleProtocolTCP  = "tcp"
+	RuleProtocolUDP  = "udp"
+	RuleProtocolICMP = "icmp"
+)
+
+// SecurityGroupError lists all the violations found when validating a
+// security group.
+type SecurityGroupError struct {
+	Errors []error
+}
+
+func (e *SecurityGroupError) Error() string {
+	msgs := make([]string, len(e.Errors))
+	for i, err := range e.Errors {
+		msgs[i] = err.Error()
+	}
+	return "invalid security group: " + strings.Join(msgs, "; ")
+}
+
+// Validate checks the rule before it is sent to the daemon. For TCP and
+// UDP rules the port range must lie within 1-65535, while for ICMP rules
+// PortRangeMin and PortRangeMax hold the ICMP type and code.
+func (r Rule) Validate() error {
+	if r.Direction != RuleDirectionIngress && r.Direction != RuleDirectionEgress {
+		return fmt.Errorf("invalid direction %q: must be %s or %s", r.Direction, RuleDirectionIngress, RuleDirectionEgress)
+	}
+	switch r.Protocol {
+	case RuleProtocolTCP, RuleProtocolUDP:
+		if r.PortRangeMin < 1 || r.PortRangeMax > 65535 {
+			return fmt.Errorf("invalid port range %d-%d: ports must be between 1 and 65535", r.PortRangeMin, r.PortRangeMax)
+		}
+		if r.PortRangeMin > r.PortRangeMax {
+			return fmt.Errorf("invalid port range %d-%d: minimum is greater than maximum", r.PortRangeMin, r.PortRangeMax)
+		}
+	case RuleProtocolICMP:
+		if r.PortRangeMin < 0 || r.PortRangeMin > 255 {
+			return fmt.Errorf("invalid ICMP type %d: must be between 0 and 255", r.PortRangeMin)
+		}
+		if r.PortRangeMax < 0 || r.PortRangeMax > 255 {
+			return fmt.Errorf("invalid ICMP code %d: must be between 0 and 255", r.PortRangeMax)
+		}
+	case "":
+	default:
+		return fmt.Errorf("invalid protocol %q: must be %s, %s, %s or empty", r.Protocol, RuleProtocolTCP, RuleProtocolUDP, RuleProtocolICMP)
+	}
+	if r.RemoteIPPrefix != "" && r.RemoteGroupName != "" {
+		return fmt.Errorf("only one of remote_ip_prefix and remote_group_name can be set")
+	}
+	if r.RemoteIPPrefix != "" {
+		if _, _, err := net.ParseCIDR(r.RemoteIPPrefix); err != nil {
+			return fmt.Errorf("invalid remote_ip_prefix %q: %v", r.RemoteIPPrefix, err)
+		}
+	}
+	return nil
+}
+
+// Validate checks the security group and all its rules. The returned
+// error is a *SecurityGroupError listing every violation found.
+func (sg SecurityGroup) Validate() error {
+	var errs []error
+	if sg.GroupName == "" {
+		errs = append(errs, fmt.Errorf("name must not be empty"))
+	}
+	for i, r := range sg.Rules {
+		if err := r.Validate(); err != nil {
+			errs = append(errs, fmt.Errorf("rule %d: %v", i, err))
+		}
+	}
+	if len(errs) > 0 {
+		return &SecurityGroupError{Errors: errs}
+	}
+	return nil
+}
+
+// ICMPRule returns a rule matching the given ICMP type and code. remote is
+// used as RemoteIPPrefix when it is a CIDR and as RemoteGroupName otherwise.
+func ICMPRule(direction string, icmpType, icmpCode int, remote string) (Rule, error) {
+	r := Rule{
+		Direction:    direction,
+		Protocol:     RuleProtocolICMP,
+		PortRangeMin: icmpType,
+		PortRangeMax: icmpCode,
+	}
+	if _, _, err := net.ParseCIDR(remote); err == nil {
+		r.RemoteIPPrefix = remote
+	} else {
+		r.RemoteGroupName = remote
+	}
+	if err := r.Validate(); err != nil {
+		return Rule{}, err
+	}
+	return r, nil
+}
+
+// ICMPTypeCode returns the ICMP type and code matched by the rule. The last
+// return value is false if the rule is not an ICMP rule.
+func (r Rule) ICMPTypeCode() (int, int, bool) {
+	if r.Protocol != RuleProtocolICMP {
+		return 0, 0, false
+	}
+	return r.PortRangeMin, r.PortRangeMax, true
+}
+
+// EtherTypes of security group rules.
+const (
+	EtherTypeIPv4 = "IPv4"
+	EtherTypeIPv6 = "IPv6"
+)
+
+// InferEtherType returns the EtherType matching the family of
+// RemoteIPPrefix, or an empty string if the prefix is empty or invalid.
+func (r Rule) InferEtherType() string {
+	if r.RemoteIPPrefix == "" {
+		return ""
+	}
+	ip, _, err := net.ParseCIDR(r.RemoteIPPrefix)
+	if err != nil {
+		return ""
+	}
+	if ip.To4() != nil {
+		return EtherTypeIPv4
+	}
+	return EtherTypeIPv6
+}
+
+// Normalize sets EtherType from RemoteIPPrefix if it is not set yet.
+func (r *Rule) Normalize() {
+	if r.EtherType == "" {
+		r.EtherType = r.InferEtherType()
+	}
+}
+
+// Normalize normalizes all the rules of the security group in place.
+func (sg *SecurityGroup) Normalize() {
+	for i := range sg.Rules {
+		sg.Rules[i].Normalize()
+	}
+}
+
+// isInternetPrefix indicates whether the remote of a rule is the whole
+// internet, which is the case when it sets neither an IP prefix nor a
+// group, or a prefix of length 0.
+func (r Rule) isInternetPrefix() bool {
+	if r.RemoteIPPrefix == "" {
+		return r.RemoteGroupName == ""
+	}
+	_, prefix, err := net.ParseCIDR(r.RemoteIPPrefix)
+	if err != nil {
+		return false
+	}
+	ones, _ := prefix.Mask.Size()
+	return ones == 0
+}
+
+type portsByTypeAndNumber []Port
+
+func (p portsByTypeAndNumber) Len() int      { return len(p) }
+func (p portsByTypeAndNumber) Swap(i, j int) { p[i], p[j] = p[j], p[i] }
+func (p portsByTypeAndNumber) Less(i, j int) bool {
+	if p[i].Type != p[j].Type {
+		return p[i].Type < p[j].Type
+	}
+	return p[i].PrivatePort < p[j].PrivatePort
+}
+
+// InternetExposedPorts returns the ports the ingress rules of the group
+// open to the whole internet, such as 0.0.0.0/0, sorted by type and number.
+// Rules for any protocol open both the TCP and UDP ports of their range,
+// or all of them if they have none, while ICMP rules open no port.
+func (sg SecurityGroup) InternetExposedPorts() []Port {
+	seen := map[Port]bool{}
+	var ports []Port
+	for _, r := range sg.Rules {
+		if r.Direction != RuleDirectionIngress || !r.isInternetPrefix() {
+			continue
+		}
+		var protocols []string
+		switch r.Protocol {
+		case RuleProtocolTCP, RuleProtocolUDP:
+			protocols = []string{r.Protocol}
+		case "":
+			protocols = []string{RuleProtocolTCP, RuleProtocolUDP}
+		default:
+			continue
+		}
+		min, max := r.PortRangeMin, r.PortRangeMax
+		if min == 0 && max == 0 {
+			min, max = 1, 65535
+		}
+		for _, proto := range protocols {
+			for n := min; n <= max; n++ {
+				p := Port{PrivatePort: n, Type: proto}
+				if !seen[p] {
+					seen[p] = true
+					ports = append(ports, p)
+				}
+			}
+		}
+	}
+	sort.Sort(portsByTypeAndNumber(ports))
+	return ports
+}
@@ -0,0 +1,137 @@
+package types
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+)
+
+// awsSecurityGroup is the JSON representation of an EC2 security group, as
+// printed by "aws ec2 describe-security-groups".
+type awsSecurityGroup struct {
+	GroupName           string
+	Description         string
+	IPPermissions       []awsIPPermission `json:"IpPermissions"`
+	IPPermissionsEgress []awsIPPermission `json:"IpPermissionsEgress"`
+}
+
+type awsIPPermission struct {
+	FromPort   *int
+	ToPort     *int
+	IPProtocol string `json:"IpProtocol"`
+	IPRanges   []struct {
+		CidrIP string `json:"CidrIp"`
+	} `json:"IpRanges"`
+	IPv6Ranges []struct {
+		CidrIPv6 string `json:"CidrIpv6"`
+	} `json:"Ipv6Ranges"`
+	UserIDGroupPairs []struct {
+		GroupName string
+		GroupID   string `json:"GroupId"`
+	} `json:"UserIdGroupPairs"`
+}
+
+// awsProtocols maps the EC2 protocol names and numbers to rule protocols.
+var awsProtocols = map[string]string{
+	"-1":   "",
+	"all":  "",
+	"tcp":  RuleProtocolTCP,
+	"6":    RuleProtocolTCP,
+	"udp":  RuleProtocolUDP,
+	"17":   RuleProtocolUDP,
+	"icmp": RuleProtocolICMP,
+	"1":    RuleProtocolICMP,
+}
+
+// FromAWSSecurityGroup converts an EC2 security group to a security group.
+// The JSON can be either a single group or the output of
+// "aws ec2 describe-security-groups" holding exactly one group. Each
+// address range or group of a permission becomes a rule, IpPermissions
+// being ingress rules and IpPermissionsEgress egress rules.
+func FromAWSSecurityGroup(r io.Reader) (SecurityGroup, error) {
+	var doc struct {
+		awsSecurityGroup
+		SecurityGroups []awsSecurityGroup
+	}
+	if err := json.NewDecoder(r).Decode(&doc); err != nil {
+		return SecurityGroup{}, fmt.Errorf("invalid AWS security group: %v", err)
+	}
+	aws := doc.awsSecurityGroup
+	if doc.SecurityGroups != nil {
+		if len(doc.SecurityGroups) != 1 {
+			return SecurityGroup{}, fmt.Errorf("expected exactly one AWS security group, got %d", len(doc.SecurityGroups))
+		}
+		aws = doc.SecurityGroups[0]
+	}
+
+	sg := SecurityGroup{GroupName: aws.GroupName, Description: aws.Description}
+	for _, p := range aws.IPPermissions {
+		rules, err := p.rules(RuleDirectionIngress)
+		if err != nil {
+			return SecurityGroup{}, err
+		}
+		sg.Rules = append(sg.Rules, rules...)
+	}
+	for _, p := range aws.IPPermissionsEgress {
+		rules, err := p.rules(RuleDirectionEgress)
+		if err != nil {
+			return SecurityGroup{}, err
+		}
+		sg.Rules = append(sg.Rules, rules...)
+	}
+	sg.Normalize()
+	return sg, nil
+}
+
+// rules returns the rules matching the permission, one per remote.
+func (p awsIPPermission) rules(direction string) ([]Rule, error) {
+	protocol, ok := awsProtocols[p.IPProtocol]
+	if !ok {
+		return nil, fmt.Errorf("unsupported AWS protocol %q", p.IPProtocol)
+	}
+	base := Rule{Direction: direction, Protocol: protocol}
+	from, to := -1, -1
+	if p.FromPort != nil {
+		from = *p.FromPort
+	}
+	if p.ToPort != nil {
+		to = *p.ToPort
+	}
+	switch protocol {
+	case RuleProtocolTCP, RuleProtocolUDP:
+		// AWS uses -1 or no port at all for the whole range.
+		if from < 0 || to < 0 {
+			from, to = 1, 65535
+		}
+		base.PortRangeMin, base.PortRangeMax = from, to
+	case RuleProtocolICMP:
+		if from < 0 || to < 0 {
+			return nil, fmt.Errorf("AWS ICMP permissions matching all types or codes are not supported")
+		}
+		base.PortRangeMin, base.PortRangeMax = from, to
+	}
+
+	var rules []Rule
+	for _, ip := range p.IPRanges {
+		r := base
+		r.RemoteIPPrefix = ip.CidrIP
+		rules = append(rules, r)
+	}
+	for _, ip := range p.IPv6Ranges {
+		r := base
+		r.RemoteIPPrefix = ip.CidrIPv6
+		rules = append(rules, r)
+	}
+	for _, g := range p.UserIDGroupPairs {
+		r := base
+		r.RemoteGroupName = g.GroupName
+		if r.RemoteGroupName == "" {
+			r.RemoteGroupName = g.GroupID
+		}
+		rules = append(rules, r)
+	}
+	if rules == nil {
+		rules = append(rules, base)
+	}
+	return rules, nil
+}
@@ -0,0 +1,73 @@
+package types
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestFromAWSSecurityGroup(t *testing.T) {
+	data := `{
+	"SecurityGroups": [{
+		"GroupName": "web",
+		"Description": "web servers",
+		"GroupId": "sg-903004f8",
+		"IpPermissions": [{
+			"IpProtocol": "tcp",
+			"FromPort": 80,
+			"ToPort": 80,
+			"IpRanges": [{"CidrIp": "0.0.0.0/0"}],
+			"Ipv6Ranges": [{"CidrIpv6": "::/0"}],
+			"UserIdGroupPairs": []
+		}],
+		"IpPermissionsEgress": [{
+			"IpProtocol": "-1",
+			"IpRanges": [{"CidrIp": "10.0.0.0/8"}],
+			"UserIdGroupPairs": []
+		}]
+	}]
+}`
+	sg, err := FromAWSSecurityGroup(strings.NewReader(data))
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := SecurityGroup{
+		GroupName:   "web",
+		Description: "web servers",
+		Rules: []Rule{
+			{Direction: "ingress", Protocol: "tcp", PortRangeMin: 80, PortRangeMax: 80, RemoteIPPrefix: "0.0.0.0/0", EtherType: "IPv4"},
+			{Direction: "ingress", Protocol: "tcp", PortRangeMin: 80, PortRangeMax: 80, RemoteIPPrefix: "::/0", EtherType: "IPv6"},
+			{Direction: "egress", RemoteIPPrefix: "10.0.0.0/8", EtherType: "IPv4"},
+		},
+	}
+	if !reflect.DeepEqual(sg, expected) {
+		t.Fatalf("expected %+v, got %+v", expected, sg)
+	}
+	if err := sg.Validate(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestFromAWSSecurityGroupSingle(t *testing.T) {
+	data := `{"GroupName": "db", "IpPermissions": [{"IpProtocol": "6", "UserIdGroupPairs": [{"GroupId": "sg-1", "GroupName": "web"}]}]}`
+	sg, err := FromAWSSecurityGroup(strings.NewReader(data))
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := []Rule{{Direction: "ingress", Protocol: "tcp", PortRangeMin: 1, PortRangeMax: 65535, RemoteGroupName: "web"}}
+	if sg.GroupName != "db" || !reflect.DeepEqual(sg.Rules, expected) {
+		t.Fatalf("unexpected security group: %+v", sg)
+	}
+
+	invalids := []string{
+		`{"SecurityGroups": []}`,
+		`{"GroupName": "x", "IpPermissions": [{"IpProtocol": "gre"}]}`,
+		`{"GroupName": "x", "IpPermissions": [{"IpProtocol": "icmp", "FromPort": -1, "ToPort": -1}]}`,
+		`not json`,
+	}
+	for _, data := range invalids {
+		if _, err := FromAWSSecurityGroup(strings.NewReader(data)); err == nil {
+			t.Fatalf("%s: expected an error", data)
+		}
+	}
+}
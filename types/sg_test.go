@@ -0,0 +1,164 @@
+package types
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestRuleValidate(t *testing.T) {
+	testCases := []struct {
+		rule          Rule
+		expectedError bool
+	}{
+		{rule: Rule{Direction: "ingress", Protocol: "tcp", PortRangeMin: 80, PortRangeMax: 80, RemoteIPPrefix: "0.0.0.0/0"}},
+		{rule: Rule{Direction: "egress", Protocol: "udp", PortRangeMin: 1, PortRangeMax: 65535}},
+		{rule: Rule{Direction: "ingress", Protocol: "icmp", PortRangeMin: 8, PortRangeMax: 0}},
+		{rule: Rule{Direction: "ingress", RemoteGroupName: "web"}},
+		{rule: Rule{Direction: "inbound", Protocol: "tcp", PortRangeMin: 80, PortRangeMax: 80}, expectedError: true},
+		{rule: Rule{Direction: "ingress", Protocol: "sctp"}, expectedError: true},
+		{rule: Rule{Direction: "ingress", Protocol: "tcp", PortRangeMin: 443, PortRangeMax: 80}, expectedError: true},
+		{rule: Rule{Direction: "ingress", Protocol: "tcp", PortRangeMin: 0, PortRangeMax: 80}, expectedError: true},
+		{rule: Rule{Direction: "ingress", Protocol: "udp", PortRangeMin: 53, PortRangeMax: 65536}, expectedError: true},
+		{rule: Rule{Direction: "ingress", Protocol: "icmp", PortRangeMin: 256}, expectedError: true},
+		{rule: Rule{Direction: "ingress", RemoteIPPrefix: "10.0.0.0/8", RemoteGroupName: "web"}, expectedError: true},
+		{rule: Rule{Direction: "ingress", RemoteIPPrefix: "10.0.0.300/8"}, expectedError: true},
+	}
+	for _, c := range testCases {
+		err := c.rule.Validate()
+		if err != nil && !c.expectedError {
+			t.Fatalf("%+v: unexpected error: %v", c.rule, err)
+		}
+		if err == nil && c.expectedError {
+			t.Fatalf("%+v: expected an error", c.rule)
+		}
+	}
+}
+
+func TestSecurityGroupValidate(t *testing.T) {
+	sg := SecurityGroup{
+		GroupName: "web",
+		Rules: []Rule{
+			{Direction: "ingress", Protocol: "tcp", PortRangeMin: 80, PortRangeMax: 80},
+		},
+	}
+	if err := sg.Validate(); err != nil {
+		t.Fatal(err)
+	}
+
+	sg = SecurityGroup{
+		Rules: []Rule{
+			{Direction: "ingress", Protocol: "tcp", PortRangeMin: 80, PortRangeMax: 80},
+			{Direction: "sideways"},
+			{Direction: "ingress", Protocol: "tcp", PortRangeMin: 90, PortRangeMax: 80},
+		},
+	}
+	err := sg.Validate()
+	sgErr, ok := err.(*SecurityGroupError)
+	if !ok {
+		t.Fatalf("expected a *SecurityGroupError, got %v", err)
+	}
+	if len(sgErr.Errors) != 3 {
+		t.Fatalf("expected 3 violations, got %d: %v", len(sgErr.Errors), err)
+	}
+}
+
+func TestICMPRule(t *testing.T) {
+	r, err := ICMPRule("ingress", 8, 0, "10.0.0.0/8")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if r.RemoteIPPrefix != "10.0.0.0/8" || r.RemoteGroupName != "" {
+		t.Fatalf("unexpected remote in %+v", r)
+	}
+	icmpType, icmpCode, ok := r.ICMPTypeCode()
+	if !ok || icmpType != 8 || icmpCode != 0 {
+		t.Fatalf("expected echo request (8, 0), got (%d, %d, %v)", icmpType, icmpCode, ok)
+	}
+
+	r, err = ICMPRule("egress", 0, 0, "web")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if r.RemoteGroupName != "web" || r.RemoteIPPrefix != "" {
+		t.Fatalf("unexpected remote in %+v", r)
+	}
+
+	if _, err := ICMPRule("ingress", 256, 0, ""); err == nil {
+		t.Fatal("expected an error for an out of range ICMP type")
+	}
+	if _, err := ICMPRule("ingress", 3, -1, ""); err == nil {
+		t.Fatal("expected an error for an out of range ICMP code")
+	}
+
+	if _, _, ok := (Rule{Protocol: "tcp"}).ICMPTypeCode(); ok {
+		t.Fatal("expected a TCP rule not to report an ICMP type and code")
+	}
+}
+
+func TestRuleInferEtherType(t *testing.T) {
+	testCases := []struct {
+		prefix   string
+		expected string
+	}{
+		{"", ""},
+		{"0.0.0.0/0", EtherTypeIPv4},
+		{"192.168.1.0/24", EtherTypeIPv4},
+		{"::/0", EtherTypeIPv6},
+		{"2001:db8::/32", EtherTypeIPv6},
+		{"not-a-cidr", ""},
+	}
+	for _, c := range testCases {
+		if got := (Rule{RemoteIPPrefix: c.prefix}).InferEtherType(); got != c.expected {
+			t.Fatalf("%q: expected %q, got %q", c.prefix, c.expected, got)
+		}
+	}
+}
+
+func TestSecurityGroupNormalize(t *testing.T) {
+	sg := SecurityGroup{
+		GroupName: "web",
+		Rules: []Rule{
+			{Direction: "ingress", RemoteIPPrefix: "10.0.0.0/8"},
+			{Direction: "ingress", RemoteIPPrefix: "fd00::/8"},
+			{Direction: "ingress", RemoteIPPrefix: "10.0.0.0/8", EtherType: EtherTypeIPv6},
+			{Direction: "ingress", RemoteGroupName: "db"},
+		},
+	}
+	sg.Normalize()
+	expected := []string{EtherTypeIPv4, EtherTypeIPv6, EtherTypeIPv6, ""}
+	for i, r := range sg.Rules {
+		if r.EtherType != expected[i] {
+			t.Fatalf("rule %d: expected %q, got %q", i, expected[i], r.EtherType)
+		}
+	}
+}
+
+func TestInternetExposedPorts(t *testing.T) {
+	sg := SecurityGroup{
+		GroupName: "web",
+		Rules: []Rule{
+			{Direction: "ingress", Protocol: "tcp", PortRangeMin: 443, PortRangeMax: 443, RemoteIPPrefix: "0.0.0.0/0"},
+			{Direction: "ingress", Protocol: "tcp", PortRangeMin: 80, PortRangeMax: 81},
+			{Direction: "ingress", Protocol: "udp", PortRangeMin: 53, PortRangeMax: 53, RemoteIPPrefix: "::/0"},
+			{Direction: "ingress", Protocol: "tcp", PortRangeMin: 22, PortRangeMax: 22, RemoteIPPrefix: "10.0.0.0/8"},
+			{Direction: "ingress", Protocol: "tcp", PortRangeMin: 5432, PortRangeMax: 5432, RemoteGroupName: "app"},
+			{Direction: "ingress", Protocol: "icmp", PortRangeMin: 8, PortRangeMax: 0, RemoteIPPrefix: "0.0.0.0/0"},
+			{Direction: "egress", Protocol: "tcp", PortRangeMin: 25, PortRangeMax: 25, RemoteIPPrefix: "0.0.0.0/0"},
+			{Direction: "ingress", Protocol: "tcp", PortRangeMin: 443, PortRangeMax: 443, RemoteIPPrefix: "0.0.0.0/0"},
+		},
+	}
+	expected := []Port{
+		{PrivatePort: 80, Type: "tcp"},
+		{PrivatePort: 81, Type: "tcp"},
+		{PrivatePort: 443, Type: "tcp"},
+		{PrivatePort: 53, Type: "udp"},
+	}
+	if ports := sg.InternetExposedPorts(); !reflect.DeepEqual(ports, expected) {
+		t.Fatalf("expected %v, got %v", expected, ports)
+	}
+
+	sg.Rules = []Rule{{Direction: "ingress", RemoteIPPrefix: "0.0.0.0/0"}}
+	if ports := sg.InternetExposedPorts(); len(ports) != 2*65535 {
+		t.Fatalf("expected all the ports to be exposed, got %d", len(ports))
+	}
+}
@@ -0,0 +1,112 @@
+package types
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"time"
+)
+
+// validNameRegexp matches the names the daemon accepts for containers,
+// volumes and snapshots.
+var validNameRegexp = regexp.MustCompile(`^[a-zA-Z0-9][a-zA-Z0-9_.-]+$`)
+
+// snapshotTimestampFormat is the layout of the timestamp in default
+// snapshot names.
+const snapshotTimestampFormat = "20060102150405"
+
+// Validate checks the request before it is sent to the daemon. The volume
+// is required; the name may be left empty to let the daemon pick one.
+func (r SnapshotCreateRequest) Validate() error {
+	if r.Volume == "" {
+		return fmt.Errorf("a volume is required to create a snapshot")
+	}
+	if r.Name != "" && !validNameRegexp.MatchString(r.Name) {
+		return fmt.Errorf("invalid snapshot name %q: only %s are allowed", r.Name, validNameRegexp.String())
+	}
+	return nil
+}
+
+// DefaultName sets the name of the snapshot to "<volume>-<timestamp>" if
+// it is empty, using clock to get the current time.
+func (r *SnapshotCreateRequest) DefaultName(clock func() time.Time) {
+	if r.Name != "" {
+		return
+	}
+	r.Name = r.Volume + "-" + clock().UTC().Format(snapshotTimestampFormat)
+}
+
+// Validate checks the request before it is sent to the daemon. The size
+// of the snapshot is not known here, see ValidateFor.
+func (r SnapshotRestoreRequest) Validate() error {
+	if r.Snapshot == "" {
+		return fmt.Errorf("a snapshot is required to restore a volume")
+	}
+	if r.Volume != "" && !validNameRegexp.MatchString(r.Volume) {
+		return fmt.Errorf("invalid volume name %q: only %s are allowed", r.Volume, validNameRegexp.String())
+	}
+	if r.Size < 0 {
+		return fmt.Errorf("invalid volume size %d: must not be negative", r.Size)
+	}
+	return nil
+}
+
+// ValidateFor validates the request for the snapshot s to restore. The
+// volume may be larger than the snapshot but not smaller.
+func (r SnapshotRestoreRequest) ValidateFor(s Snapshot) error {
+	if err := r.Validate(); err != nil {
+		return err
+	}
+	if r.Size != 0 && r.Size < s.Size {
+		return fmt.Errorf("invalid volume size %dGB: snapshot %s is %dGB", r.Size, r.Snapshot, s.Size)
+	}
+	return nil
+}
+
+// VolumeCreateRequest returns the request creating the volume provisioned
+// from the snapshot. A Size other than 0 is passed as the "size" driver
+// option.
+func (r SnapshotRestoreRequest) VolumeCreateRequest() VolumeCreateRequest {
+	req := VolumeCreateRequest{
+		Name:     r.Volume,
+		Snapshot: r.Snapshot,
+	}
+	if r.Size != 0 {
+		req.DriverOpts = map[string]string{"size": strconv.Itoa(r.Size)}
+	}
+	return req
+}
+
+// SnapshotChain returns the chain of snapshots from the snapshot leaf,
+// given by ID or name, back to its root by following Parent. It returns an
+// error if a snapshot of the chain is missing or if the chain has a cycle.
+func SnapshotChain(snaps []*Snapshot, leaf string) ([]*Snapshot, error) {
+	byID := make(map[string]*Snapshot, len(snaps))
+	byName := make(map[string]*Snapshot, len(snaps))
+	for _, s := range snaps {
+		byID[s.ID] = s
+		byName[s.Name] = s
+	}
+	find := func(ref string) *Snapshot {
+		if s, ok := byID[ref]; ok {
+			return s
+		}
+		return byName[ref]
+	}
+
+	var chain []*Snapshot
+	seen := map[*Snapshot]bool{}
+	for ref := leaf; ref != ""; {
+		s := find(ref)
+		if s == nil {
+			return nil, fmt.Errorf("no such snapshot: %s", ref)
+		}
+		if seen[s] {
+			return nil, fmt.Errorf("snapshot chain of %s has a cycle at %s", leaf, ref)
+		}
+		seen[s] = true
+		chain = append(chain, s)
+		ref = s.Parent
+	}
+	return chain, nil
+}
@@ -0,0 +1,120 @@
+package types
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestSnapshotCreateRequestDefaultName(t *testing.T) {
+	clock := func() time.Time {
+		return time.Date(2017, time.March, 4, 5, 6, 7, 0, time.UTC)
+	}
+	r := SnapshotCreateRequest{Volume: "data"}
+	r.DefaultName(clock)
+	if r.Name != "data-20170304050607" {
+		t.Fatalf("expected data-20170304050607, got %s", r.Name)
+	}
+	if err := r.Validate(); err != nil {
+		t.Fatal(err)
+	}
+
+	r = SnapshotCreateRequest{Volume: "data", Name: "nightly"}
+	r.DefaultName(clock)
+	if r.Name != "nightly" {
+		t.Fatalf("expected the name to be kept, got %s", r.Name)
+	}
+}
+
+func TestSnapshotCreateRequestValidate(t *testing.T) {
+	testCases := []struct {
+		request       SnapshotCreateRequest
+		expectedError bool
+	}{
+		{SnapshotCreateRequest{Volume: "data"}, false},
+		{SnapshotCreateRequest{Volume: "data", Name: "data.snap-1"}, false},
+		{SnapshotCreateRequest{Name: "snap"}, true},
+		{SnapshotCreateRequest{Volume: "data", Name: "-snap"}, true},
+		{SnapshotCreateRequest{Volume: "data", Name: "snap/1"}, true},
+	}
+	for _, c := range testCases {
+		err := c.request.Validate()
+		if err != nil && !c.expectedError {
+			t.Fatalf("%+v: unexpected error: %v", c.request, err)
+		}
+		if err == nil && c.expectedError {
+			t.Fatalf("%+v: expected an error", c.request)
+		}
+	}
+}
+
+func TestSnapshotRestoreRequest(t *testing.T) {
+	snapshot := Snapshot{ID: "abc", Name: "db-20170102", Volume: "db", Size: 10}
+
+	r := SnapshotRestoreRequest{Snapshot: "db-20170102", Volume: "db-restored", Size: 20}
+	if err := r.ValidateFor(snapshot); err != nil {
+		t.Fatal(err)
+	}
+	req := r.VolumeCreateRequest()
+	if req.Name != "db-restored" || req.Snapshot != "db-20170102" || req.DriverOpts["size"] != "20" {
+		t.Fatalf("unexpected volume create request: %+v", req)
+	}
+
+	r.Size = 0
+	if err := r.ValidateFor(snapshot); err != nil {
+		t.Fatal(err)
+	}
+	if req := r.VolumeCreateRequest(); req.DriverOpts != nil {
+		t.Fatalf("expected no driver options, got %v", req.DriverOpts)
+	}
+
+	r.Size = 5
+	if err := r.ValidateFor(snapshot); err == nil {
+		t.Fatal("expected an error when restoring into a smaller volume")
+	}
+	if err := (SnapshotRestoreRequest{Volume: "db"}).Validate(); err == nil {
+		t.Fatal("expected an error without a snapshot")
+	}
+	if err := (SnapshotRestoreRequest{Snapshot: "s", Size: -1}).Validate(); err == nil {
+		t.Fatal("expected an error for a negative size")
+	}
+}
+
+func TestSnapshotChain(t *testing.T) {
+	var snaps []*Snapshot
+	if err := json.Unmarshal([]byte(`[
+		{"ID": "s1", "Name": "base", "Volume": "db"},
+		{"ID": "s2", "Name": "daily", "Volume": "db", "Parent": "s1"},
+		{"ID": "s3", "Name": "hourly", "Volume": "db", "Parent": "s2"},
+		{"ID": "s4", "Name": "other", "Volume": "web"}
+	]`), &snaps); err != nil {
+		t.Fatal(err)
+	}
+	chain, err := SnapshotChain(snaps, "hourly")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var ids []string
+	for _, s := range chain {
+		ids = append(ids, s.ID)
+	}
+	if expected := []string{"s3", "s2", "s1"}; !reflect.DeepEqual(ids, expected) {
+		t.Fatalf("expected chain %v, got %v", expected, ids)
+	}
+	if chain, err := SnapshotChain(snaps, "s4"); err != nil || len(chain) != 1 {
+		t.Fatalf("expected a single snapshot chain, got %v, %v", chain, err)
+	}
+
+	snaps[0].Parent = "s3"
+	if _, err := SnapshotChain(snaps, "s3"); err == nil {
+		t.Fatal("expected an error for a cycle")
+	}
+	snaps[0].Parent = "missing"
+	if _, err := SnapshotChain(snaps, "s3"); err == nil {
+		t.Fatal("expected an error for a missing parent")
+	}
+	if _, err := SnapshotChain(snaps, "nope"); err == nil {
+		t.Fatal("expected an error for a missing leaf")
+	}
+}
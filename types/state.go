@@ -0,0 +1,97 @@
+package types
+
+import (
+	"fmt"
+	"time"
+)
+
+// CanPause indicates whether the container is in a state that allows it to
+// be paused, which is running and neither paused nor restarting.
+func (s *ContainerState) CanPause() bool {
+	return s.Running && !s.Paused && !s.Restarting
+}
+
+// CanUnpause indicates whether the container is paused and can therefore
+// be unpaused.
+func (s *ContainerState) CanUnpause() bool {
+	return s.Paused
+}
+
+// ExpectedRunning indicates whether the restart policy of the container
+// asks for it to be kept running, which is the case for the "always" and
+// "unless-stopped" policies. The API does not report whether a container
+// was stopped by the user, so an "unless-stopped" container that exited
+// cleanly is assumed to have been stopped explicitly.
+func (c *ContainerJSON) ExpectedRunning() bool {
+	if c.ContainerJSONBase == nil || c.HostConfig == nil {
+		return false
+	}
+	policy := c.HostConfig.RestartPolicy
+	switch {
+	case policy.IsAlways():
+		return true
+	case policy.IsUnlessStopped():
+		return c.State == nil || c.State.Running || c.State.ExitCode != 0
+	}
+	return false
+}
+
+// UnexpectedlyStopped returns the containers whose restart policy expects
+// them to be running but that are neither running nor restarting.
+func UnexpectedlyStopped(cs []*ContainerJSON) []*ContainerJSON {
+	var stopped []*ContainerJSON
+	for _, c := range cs {
+		if !c.ExpectedRunning() || c.State == nil {
+			continue
+		}
+		if !c.State.Running && !c.State.Restarting {
+			stopped = append(stopped, c)
+		}
+	}
+	return stopped
+}
+
+// RestartRate approximates how many times the container restarted per
+// window, spreading RestartCount evenly over the age of the container
+// (from Created to now, usually time.Now()). Containers younger than window
+// report their RestartCount as is.
+func (c *ContainerJSON) RestartRate(window time.Duration, now time.Time) (float64, error) {
+	if window <= 0 {
+		return 0, fmt.Errorf("invalid restart rate window: %v", window)
+	}
+	if c.ContainerJSONBase == nil {
+		return 0, nil
+	}
+	created, err := time.Parse(time.RFC3339Nano, c.Created)
+	if err != nil {
+		return 0, fmt.Errorf("invalid Created %q: %v", c.Created, err)
+	}
+	age := now.Sub(created)
+	if age < window {
+		age = window
+	}
+	return float64(c.RestartCount) * float64(window) / float64(age), nil
+}
+
+// IsReady is a heuristic telling whether the container can serve traffic:
+// it must be running, its health check, if any, must be passing, and at
+// least one of its ports must be published on the host.
+func (c *ContainerJSON) IsReady() bool {
+	if c.ContainerJSONBase == nil || c.State == nil || !c.State.Running {
+		return false
+	}
+	if c.State.Health != nil && c.State.Health.Status != Healthy {
+		return false
+	}
+	if c.NetworkSettings == nil {
+		return false
+	}
+	for _, bindings := range c.NetworkSettings.Ports {
+		for _, b := range bindings {
+			if b.HostPort != "" {
+				return true
+			}
+		}
+	}
+	return false
+}
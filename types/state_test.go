@@ -0,0 +1,125 @@
+package types
+
+import (
+	"testing"
+	"time"
+
+	"github.com/docker/go-connections/nat"
+	"github.com/hyperhq/hyper-api/types/container"
+)
+
+func TestContainerStatePauseGuards(t *testing.T) {
+	testCases := []struct {
+		name       string
+		state      ContainerState
+		canPause   bool
+		canUnpause bool
+	}{
+		{"running", ContainerState{Status: "running", Running: true}, true, false},
+		{"paused", ContainerState{Status: "paused", Running: true, Paused: true}, false, true},
+		{"restarting", ContainerState{Status: "restarting", Running: true, Restarting: true}, false, false},
+		{"exited", ContainerState{Status: "exited"}, false, false},
+	}
+	for _, c := range testCases {
+		if actual := c.state.CanPause(); actual != c.canPause {
+			t.Fatalf("%s: expected CanPause %v, got %v", c.name, c.canPause, actual)
+		}
+		if actual := c.state.CanUnpause(); actual != c.canUnpause {
+			t.Fatalf("%s: expected CanUnpause %v, got %v", c.name, c.canUnpause, actual)
+		}
+	}
+}
+
+func newContainerJSON(id, policy string, state *ContainerState) *ContainerJSON {
+	return &ContainerJSON{
+		ContainerJSONBase: &ContainerJSONBase{
+			ID:         id,
+			State:      state,
+			HostConfig: &container.HostConfig{RestartPolicy: container.RestartPolicy{Name: policy}},
+		},
+	}
+}
+
+func TestUnexpectedlyStopped(t *testing.T) {
+	cs := []*ContainerJSON{
+		newContainerJSON("always-stopped", "always", &ContainerState{Status: "exited", ExitCode: 0}),
+		newContainerJSON("always-running", "always", &ContainerState{Status: "running", Running: true}),
+		newContainerJSON("no-stopped", "no", &ContainerState{Status: "exited", ExitCode: 1}),
+		newContainerJSON("unless-stopped-clean", "unless-stopped", &ContainerState{Status: "exited"}),
+		newContainerJSON("unless-stopped-crashed", "unless-stopped", &ContainerState{Status: "exited", ExitCode: 2}),
+		newContainerJSON("always-restarting", "always", &ContainerState{Status: "restarting", Restarting: true}),
+	}
+	stopped := UnexpectedlyStopped(cs)
+	if len(stopped) != 2 {
+		t.Fatalf("expected 2 unexpectedly stopped containers, got %d", len(stopped))
+	}
+	if stopped[0].ID != "always-stopped" || stopped[1].ID != "unless-stopped-crashed" {
+		t.Fatalf("unexpected containers flagged: %s, %s", stopped[0].ID, stopped[1].ID)
+	}
+}
+
+func TestExpectedRunningWithoutHostConfig(t *testing.T) {
+	if (&ContainerJSON{}).ExpectedRunning() {
+		t.Fatal("expected a container without host config not to be expected running")
+	}
+}
+
+func TestRestartRate(t *testing.T) {
+	now := time.Date(2017, 1, 21, 12, 0, 0, 0, time.UTC)
+	c := &ContainerJSON{
+		ContainerJSONBase: &ContainerJSONBase{
+			Created:      now.Add(-10 * time.Hour).Format(time.RFC3339Nano),
+			RestartCount: 5,
+		},
+	}
+	rate, err := c.RestartRate(time.Hour, now)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if rate != 0.5 {
+		t.Fatalf("expected 0.5 restarts per hour, got %f", rate)
+	}
+
+	c.Created = now.Add(-time.Minute).Format(time.RFC3339Nano)
+	if rate, err := c.RestartRate(time.Hour, now); err != nil || rate != 5 {
+		t.Fatalf("expected 5 restarts for a container younger than the window, got %f (%v)", rate, err)
+	}
+
+	if _, err := c.RestartRate(0, now); err == nil {
+		t.Fatal("expected an error for an empty window")
+	}
+	c.Created = "yesterday"
+	if _, err := c.RestartRate(time.Hour, now); err == nil {
+		t.Fatal("expected an error for a malformed creation time")
+	}
+}
+
+func TestIsReady(t *testing.T) {
+	bound := &NetworkSettings{NetworkSettingsBase: NetworkSettingsBase{Ports: nat.PortMap{
+		"80/tcp": []nat.PortBinding{{HostIP: "0.0.0.0", HostPort: "80"}},
+	}}}
+	unbound := &NetworkSettings{NetworkSettingsBase: NetworkSettingsBase{Ports: nat.PortMap{
+		"80/tcp": nil,
+	}}}
+	testCases := []struct {
+		state    *ContainerState
+		network  *NetworkSettings
+		expected bool
+	}{
+		{&ContainerState{Running: true, Health: &Health{Status: Healthy}}, bound, true},
+		{&ContainerState{Running: true}, bound, true},
+		{&ContainerState{Running: true, Health: &Health{Status: Unhealthy}}, bound, false},
+		{&ContainerState{Running: true, Health: &Health{Status: Starting}}, bound, false},
+		{&ContainerState{Running: true, Health: &Health{Status: Healthy}}, unbound, false},
+		{&ContainerState{Running: true}, nil, false},
+		{&ContainerState{Health: &Health{Status: Healthy}}, bound, false},
+		{nil, bound, false},
+	}
+	for i, c := range testCases {
+		cj := newContainerJSON("c", "no", c.state)
+		cj.NetworkSettings = c.network
+		if ready := cj.IsReady(); ready != c.expected {
+			t.Fatalf("%d: expected %v, got %v", i, c.expected, ready)
+		}
+	}
+}
@@ -2,7 +2,12 @@
 // consumers of the API stats endpoint.
 package types
 
-import "time"
+import (
+	"encoding/json"
+	"io"
+	"sort"
+	"time"
+)
 
 // ThrottlingData stores CPU throttling stats of one running container
 type ThrottlingData struct {
@@ -34,6 +39,7 @@ type CPUUsage struct {
 type CPUStats struct {
 	CPUUsage       CPUUsage       `json:"cpu_usage"`
 	SystemUsage    uint64         `json:"system_cpu_usage"`
+	OnlineCPUs     uint32         `json:"online_cpus,omitempty"`
 	ThrottlingData ThrottlingData `json:"throttling_data,omitempty"`
 }
 
@@ -113,3 +119,95 @@ type StatsJSON struct {
 	// Networks request version >=1.21
 	Networks map[string]NetworkStats `json:"networks,omitempty"`
 }
+
+// ContainerStats is the payload returned by the stats endpoint:
+// GET "/containers/{name:.*}/stats"
+// It carries the CPU, memory, block IO and per-interface network counters
+// of one container.
+type ContainerStats struct {
+	ID   string `json:"id,omitempty"`
+	Name string `json:"name,omitempty"`
+	StatsJSON
+}
+
+// StatsReader decodes the stats of a container one frame at a time from
+// the body of a stats response. It works for streamed responses, which
+// carry one JSON object per line, as well as for single-shot responses
+// (stream=false), which carry a single object.
+type StatsReader struct {
+	rc  io.ReadCloser
+	dec *json.Decoder
+}
+
+// NewStatsReader returns a StatsReader reading from rc.
+func NewStatsReader(rc io.ReadCloser) *StatsReader {
+	return &StatsReader{rc: rc, dec: json.NewDecoder(rc)}
+}
+
+// Decode reads the next stats frame into stats. It returns io.EOF once
+// the response has been fully consumed.
+func (r *StatsReader) Decode(stats *ContainerStats) error {
+	return r.dec.Decode(stats)
+}
+
+// Close closes the underlying response body.
+func (r *StatsReader) Close() error {
+	return r.rc.Close()
+}
+
+// InterfaceNames returns the names of the network interfaces the stats
+// have counters for, sorted.
+func (s *StatsJSON) InterfaceNames() []string {
+	names := make([]string, 0, len(s.Networks))
+	for name := range s.Networks {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// NetworkFor returns the network counters of the interface iface.
+func (s *StatsJSON) NetworkFor(iface string) (NetworkStats, bool) {
+	stats, ok := s.Networks[iface]
+	return stats, ok
+}
+
+// CalculateCPUPercent returns the CPU usage of the container between two
+// samples of its stats, as shown by `hyper stats`: the share of the system
+// CPU time it used, multiplied by the number of online CPUs so that a
+// container using two CPUs fully is at 200%. It returns 0 for the first
+// sample, when previous is zero.
+func CalculateCPUPercent(previous, current ContainerStats) float64 {
+	prev := previous.CPUStats
+	if prev.CPUUsage.TotalUsage == 0 && prev.SystemUsage == 0 {
+		return 0
+	}
+	cpuDelta := float64(current.CPUStats.CPUUsage.TotalUsage) - float64(prev.CPUUsage.TotalUsage)
+	systemDelta := float64(current.CPUStats.SystemUsage) - float64(prev.SystemUsage)
+	if cpuDelta <= 0 || systemDelta <= 0 {
+		return 0
+	}
+	cpus := float64(current.CPUStats.OnlineCPUs)
+	if cpus == 0 {
+		cpus = float64(len(current.CPUStats.CPUUsage.PercpuUsage))
+	}
+	if cpus == 0 {
+		cpus = 1
+	}
+	return cpuDelta / systemDelta * cpus * 100
+}
+
+// CalculateMemoryUsage returns the memory used by the container, not
+// counting the page cache, its memory limit and the share of the limit
+// used, as shown by `hyper stats`. The share is 0 when there is no limit.
+func CalculateMemoryUsage(stats ContainerStats) (usage, limit uint64, percent float64) {
+	usage = stats.MemoryStats.Usage
+	if cache := stats.MemoryStats.Stats["cache"]; cache <= usage {
+		usage -= cache
+	}
+	limit = stats.MemoryStats.Limit
+	if limit != 0 {
+		percent = float64(usage) / float64(limit) * 100
+	}
+	return usage, limit, percent
+}
@@ -0,0 +1,127 @@
+package types
+
+import (
+	"encoding/json"
+	"io"
+	"io/ioutil"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestStatsNetworkInterfaces(t *testing.T) {
+	body := `{
+		"read": "2017-01-02T15:04:05.123456789Z",
+		"networks": {
+			"eth1": {"rx_bytes": 100, "tx_bytes": 200},
+			"eth0": {"rx_bytes": 5419, "rx_packets": 52, "tx_bytes": 648, "tx_packets": 8}
+		}
+	}`
+	var stats StatsJSON
+	if err := json.Unmarshal([]byte(body), &stats); err != nil {
+		t.Fatal(err)
+	}
+	if names := stats.InterfaceNames(); !reflect.DeepEqual(names, []string{"eth0", "eth1"}) {
+		t.Fatalf("unexpected interface names: %v", names)
+	}
+	eth0, ok := stats.NetworkFor("eth0")
+	if !ok {
+		t.Fatal("expected stats for eth0")
+	}
+	expected := NetworkStats{RxBytes: 5419, RxPackets: 52, TxBytes: 648, TxPackets: 8}
+	if eth0 != expected {
+		t.Fatalf("expected %+v, got %+v", expected, eth0)
+	}
+	if _, ok := stats.NetworkFor("lo"); ok {
+		t.Fatal("expected no stats for lo")
+	}
+}
+
+func TestStatsReaderStream(t *testing.T) {
+	body := `{"id":"ed3d5c3a9e11","name":"/web","read":"2017-01-02T15:04:05Z","cpu_stats":{"cpu_usage":{"total_usage":100},"system_cpu_usage":1000},"memory_stats":{"usage":1024,"limit":4096}}
+{"id":"ed3d5c3a9e11","name":"/web","read":"2017-01-02T15:04:06Z","cpu_stats":{"cpu_usage":{"total_usage":200},"system_cpu_usage":2000},"memory_stats":{"usage":2048,"limit":4096},"networks":{"eth0":{"rx_bytes":10,"tx_bytes":20}}}
+`
+	reader := NewStatsReader(ioutil.NopCloser(strings.NewReader(body)))
+	defer reader.Close()
+
+	var frames []ContainerStats
+	for {
+		var stats ContainerStats
+		err := reader.Decode(&stats)
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+		frames = append(frames, stats)
+	}
+	if len(frames) != 2 {
+		t.Fatalf("expected 2 frames, got %d", len(frames))
+	}
+	last := frames[1]
+	if last.ID != "ed3d5c3a9e11" || last.Name != "/web" {
+		t.Fatalf("unexpected container identity: %s %s", last.ID, last.Name)
+	}
+	if last.CPUStats.CPUUsage.TotalUsage != 200 || last.MemoryStats.Usage != 2048 || last.MemoryStats.Limit != 4096 {
+		t.Fatalf("unexpected counters: %+v", last.Stats)
+	}
+	if eth0, ok := last.NetworkFor("eth0"); !ok || eth0.RxBytes != 10 || eth0.TxBytes != 20 {
+		t.Fatalf("unexpected eth0 counters: %+v", eth0)
+	}
+}
+
+func TestStatsReaderSingleShot(t *testing.T) {
+	body := `{"read":"2017-01-02T15:04:05Z","memory_stats":{"usage":1024,"limit":4096}}`
+	reader := NewStatsReader(ioutil.NopCloser(strings.NewReader(body)))
+
+	var stats ContainerStats
+	if err := reader.Decode(&stats); err != nil {
+		t.Fatal(err)
+	}
+	if stats.MemoryStats.Usage != 1024 {
+		t.Fatalf("expected memory usage 1024, got %d", stats.MemoryStats.Usage)
+	}
+	if err := reader.Decode(&stats); err != io.EOF {
+		t.Fatalf("expected io.EOF after the single frame, got %v", err)
+	}
+}
+
+func TestCalculateCPUPercent(t *testing.T) {
+	sample := func(total, system uint64, online uint32) ContainerStats {
+		var s ContainerStats
+		s.CPUStats = CPUStats{
+			CPUUsage:    CPUUsage{TotalUsage: total, PercpuUsage: []uint64{total / 2, total / 2}},
+			SystemUsage: system,
+			OnlineCPUs:  online,
+		}
+		return s
+	}
+	testCases := []struct {
+		previous, current ContainerStats
+		expected          float64
+	}{
+		{ContainerStats{}, sample(1e9, 1e10, 4), 0},
+		{sample(1e9, 1e10, 4), sample(2e9, 2e10, 4), 40},
+		{sample(1e9, 1e10, 0), sample(2e9, 2e10, 0), 20},
+		{sample(1e9, 1e10, 4), sample(1e9, 2e10, 4), 0},
+		{sample(2e9, 2e10, 4), sample(1e9, 1e10, 4), 0},
+	}
+	for i, c := range testCases {
+		if percent := CalculateCPUPercent(c.previous, c.current); percent != c.expected {
+			t.Fatalf("case %d: expected %v%%, got %v%%", i, c.expected, percent)
+		}
+	}
+}
+
+func TestCalculateMemoryUsage(t *testing.T) {
+	var stats ContainerStats
+	stats.MemoryStats = MemoryStats{Usage: 300 << 20, Limit: 1 << 30, Stats: map[string]uint64{"cache": 44 << 20}}
+	usage, limit, percent := CalculateMemoryUsage(stats)
+	if usage != 256<<20 || limit != 1<<30 || percent != 25 {
+		t.Fatalf("unexpected memory usage %d/%d (%v%%)", usage, limit, percent)
+	}
+	if _, _, percent := CalculateMemoryUsage(ContainerStats{}); percent != 0 {
+		t.Fatalf("expected 0%% without a limit, got %v%%", percent)
+	}
+}
@@ -0,0 +1,75 @@
+// Package stdcopy demultiplexes the attach and logs streams of containers
+// created without a TTY, in which stdout and stderr are interleaved as
+// frames.
+package stdcopy
+
+import (
+	"encoding/binary"
+	"fmt"
+	"io"
+	"io/ioutil"
+)
+
+// StdType is the stream a frame belongs to, as given by the first byte of
+// its header.
+type StdType byte
+
+const (
+	// Stdin is the stream of the input written back to the client
+	Stdin StdType = iota
+	// Stdout is the standard output stream
+	Stdout
+	// Stderr is the standard error stream
+	Stderr
+)
+
+// headerLen is the size of the header of a frame: the stream type, three
+// bytes of padding and the big-endian length of the payload.
+const headerLen = 8
+
+// StdCopy copies the payload of the frames read from src to dstout or
+// dsterr, depending on their stream, until src returns io.EOF. Stdin frames
+// are copied to dstout, and the frames of a nil writer are discarded. It
+// returns the number of payload bytes written, and an error if src ends in
+// the middle of a frame or a frame has an unknown stream type.
+func StdCopy(dstout, dsterr io.Writer, src io.Reader) (written int64, err error) {
+	if dstout == nil {
+		dstout = ioutil.Discard
+	}
+	if dsterr == nil {
+		dsterr = ioutil.Discard
+	}
+	var header [headerLen]byte
+	for {
+		// io.ReadFull reassembles headers and payloads split across reads.
+		if _, err := io.ReadFull(src, header[:]); err != nil {
+			if err == io.EOF {
+				return written, nil
+			}
+			if err == io.ErrUnexpectedEOF {
+				return written, fmt.Errorf("stdcopy: truncated frame header")
+			}
+			return written, err
+		}
+
+		var dst io.Writer
+		switch StdType(header[0]) {
+		case Stdin, Stdout:
+			dst = dstout
+		case Stderr:
+			dst = dsterr
+		default:
+			return written, fmt.Errorf("stdcopy: unknown stream type %d", header[0])
+		}
+
+		size := int64(binary.BigEndian.Uint32(header[4:]))
+		n, err := io.CopyN(dst, src, size)
+		written += n
+		if err == io.EOF {
+			return written, fmt.Errorf("stdcopy: truncated frame: got %d of %d bytes", n, size)
+		}
+		if err != nil {
+			return written, err
+		}
+	}
+}
@@ -0,0 +1,81 @@
+package stdcopy
+
+import (
+	"bytes"
+	"encoding/binary"
+	"io"
+	"strings"
+	"testing"
+	"testing/iotest"
+)
+
+func frame(stream StdType, payload string) []byte {
+	header := make([]byte, headerLen)
+	header[0] = byte(stream)
+	binary.BigEndian.PutUint32(header[4:], uint32(len(payload)))
+	return append(header, payload...)
+}
+
+func multiplexed() []byte {
+	var buf bytes.Buffer
+	buf.Write(frame(Stdout, "hello\n"))
+	buf.Write(frame(Stderr, "warning: low disk\n"))
+	buf.Write(frame(Stdout, strings.Repeat("x", 40000)))
+	buf.Write(frame(Stdout, ""))
+	buf.Write(frame(Stdin, "typed\n"))
+	return buf.Bytes()
+}
+
+func TestStdCopy(t *testing.T) {
+	expectedOut := "hello\n" + strings.Repeat("x", 40000) + "typed\n"
+	expectedErr := "warning: low disk\n"
+	readers := map[string]func(io.Reader) io.Reader{
+		"whole":    func(r io.Reader) io.Reader { return r },
+		"one byte": iotest.OneByteReader,
+		"half":     iotest.HalfReader,
+	}
+	for name, wrap := range readers {
+		var stdout, stderr bytes.Buffer
+		written, err := StdCopy(&stdout, &stderr, wrap(bytes.NewReader(multiplexed())))
+		if err != nil {
+			t.Fatalf("%s: %v", name, err)
+		}
+		if stdout.String() != expectedOut || stderr.String() != expectedErr {
+			t.Fatalf("%s: unexpected output %q, %q", name, stdout.String(), stderr.String())
+		}
+		if expected := int64(len(expectedOut) + len(expectedErr)); written != expected {
+			t.Fatalf("%s: expected %d bytes written, got %d", name, expected, written)
+		}
+	}
+}
+
+func TestStdCopyNilWriter(t *testing.T) {
+	var stdout bytes.Buffer
+	if _, err := StdCopy(&stdout, nil, bytes.NewReader(multiplexed())); err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(stdout.String(), "warning") {
+		t.Fatalf("expected stderr to be discarded, got %q", stdout.String())
+	}
+}
+
+func TestStdCopyErrors(t *testing.T) {
+	testCases := []struct {
+		input    []byte
+		expected string
+	}{
+		{frame(Stdout, "hello")[:5], "stdcopy: truncated frame header"},
+		{frame(Stdout, "hello")[:10], "stdcopy: truncated frame: got 2 of 5 bytes"},
+		{frame(StdType(7), "hello"), "stdcopy: unknown stream type 7"},
+	}
+	for _, c := range testCases {
+		var stdout, stderr bytes.Buffer
+		_, err := StdCopy(&stdout, &stderr, iotest.OneByteReader(bytes.NewReader(c.input)))
+		if err == nil || err.Error() != c.expected {
+			t.Fatalf("expected error %q, got %v", c.expected, err)
+		}
+	}
+	if written, err := StdCopy(nil, nil, bytes.NewReader(nil)); written != 0 || err != nil {
+		t.Fatalf("expected an empty copy, got %d, %v", written, err)
+	}
+}
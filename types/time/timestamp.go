@@ -122,3 +122,22 @@ func ParseTimestamps(value string, def int64) (int64, int64, error) {
 	n = int64(float64(n) * math.Pow(float64(10), float64(9-len(sa[1]))))
 	return s, n, nil
 }
+
+// NormalizeLogTime converts the Since or Until value of log options, which
+// can be a Unix timestamp, an RFC3339 time or a duration relative to now
+// such as "10m", to the Unix timestamp in seconds the daemon expects. The
+// fractional part is only kept when it is not zero.
+func NormalizeLogTime(value string) (string, error) {
+	ts, err := GetTimestamp(value, time.Now())
+	if err != nil {
+		return "", err
+	}
+	sec, nsec, err := ParseTimestamps(ts, 0)
+	if err != nil || value == "" {
+		return "", fmt.Errorf("invalid time %q: expected a Unix timestamp, an RFC3339 time or a duration", value)
+	}
+	if nsec == 0 {
+		return strconv.FormatInt(sec, 10), nil
+	}
+	return fmt.Sprintf("%d.%09d", sec, nsec), nil
+}
@@ -91,3 +91,39 @@ func TestParseTimestamps(t *testing.T) {
 		}
 	}
 }
+
+func TestNormalizeLogTime(t *testing.T) {
+	cases := []struct {
+		in, expected string
+	}{
+		{"2017-01-21T11:32:07Z", "1484998327"},
+		{"2017-01-21T12:32:07.5+01:00", "1484998327.500000000"},
+		{"1484998327", "1484998327"},
+		{"1484998327.000000001", "1484998327.000000001"},
+	}
+	for _, c := range cases {
+		if out, err := NormalizeLogTime(c.in); err != nil || out != c.expected {
+			t.Fatalf("%s: expected %s, got %s, %v", c.in, c.expected, out, err)
+		}
+	}
+
+	before := time.Now().Add(-10 * time.Minute).Unix()
+	out, err := NormalizeLogTime("10m")
+	if err != nil {
+		t.Fatal(err)
+	}
+	after := time.Now().Add(-10 * time.Minute).Unix()
+	sec, _, err := ParseTimestamps(out, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if sec < before || sec > after {
+		t.Fatalf("expected a timestamp between %d and %d, got %s", before, after, out)
+	}
+
+	for _, in := range []string{"", "yesterday", "2017-13-45"} {
+		if _, err := NormalizeLogTime(in); err == nil {
+			t.Fatalf("expected an error for %q", in)
+		}
+	}
+}
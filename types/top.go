@@ -0,0 +1,54 @@
+package types
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Rows returns the processes of the list as maps from column title to
+// value. Columns missing from a process map to empty strings.
+func (p ContainerProcessList) Rows() []map[string]string {
+	rows := make([]map[string]string, 0, len(p.Processes))
+	for _, process := range p.Processes {
+		row := make(map[string]string, len(p.Titles))
+		for i, title := range p.Titles {
+			if i < len(process) {
+				row[title] = process[i]
+			} else {
+				row[title] = ""
+			}
+		}
+		rows = append(rows, row)
+	}
+	return rows
+}
+
+// PIDs returns the process IDs found in the "PID" column of the list,
+// matched case-insensitively. It returns an error if there is no such
+// column or if a value in it is missing or not a number.
+func (p ContainerProcessList) PIDs() ([]int, error) {
+	col := -1
+	for i, title := range p.Titles {
+		if strings.EqualFold(title, "PID") {
+			col = i
+			break
+		}
+	}
+	if col < 0 {
+		return nil, fmt.Errorf("no PID column in process list titles %v", p.Titles)
+	}
+
+	pids := make([]int, 0, len(p.Processes))
+	for _, row := range p.Processes {
+		if col >= len(row) {
+			return nil, fmt.Errorf("process %v has no PID column", row)
+		}
+		pid, err := strconv.Atoi(row[col])
+		if err != nil {
+			return nil, fmt.Errorf("invalid PID %q: %v", row[col], err)
+		}
+		pids = append(pids, pid)
+	}
+	return pids, nil
+}
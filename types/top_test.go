@@ -0,0 +1,74 @@
+package types
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestTopOptionsToQuery(t *testing.T) {
+	if query := (TopOptions{}).ToQuery(); len(query) != 0 {
+		t.Fatalf("expected an empty query, got %v", query)
+	}
+	query := TopOptions{PsArgs: "-o pid,user,args"}.ToQuery()
+	if actual := query.Encode(); actual != "ps_args=-o+pid%2Cuser%2Cargs" {
+		t.Fatalf("unexpected query: %s", actual)
+	}
+}
+
+func TestContainerProcessListPIDs(t *testing.T) {
+	list := ContainerProcessList{
+		Titles: []string{"UID", "PID", "PPID", "CMD"},
+		Processes: [][]string{
+			{"root", "1", "0", "nginx: master process"},
+			{"nginx", "7", "1", "nginx: worker process"},
+		},
+	}
+	pids, err := list.PIDs()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(pids, []int{1, 7}) {
+		t.Fatalf("expected PIDs [1 7], got %v", pids)
+	}
+
+	list.Titles = []string{"UID", "CMD"}
+	if _, err := list.PIDs(); err == nil {
+		t.Fatal("expected an error when there is no PID column")
+	}
+}
+
+func TestContainerProcessListPIDsCaseInsensitive(t *testing.T) {
+	list := ContainerProcessList{
+		Titles:    []string{"user", "pid", "command"},
+		Processes: [][]string{{"root", "1", "init"}},
+	}
+	pids, err := list.PIDs()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(pids, []int{1}) {
+		t.Fatalf("expected PIDs [1], got %v", pids)
+	}
+
+	list.Processes = append(list.Processes, []string{"root"})
+	if _, err := list.PIDs(); err == nil {
+		t.Fatal("expected an error for a process without a PID")
+	}
+}
+
+func TestContainerProcessListRows(t *testing.T) {
+	list := ContainerProcessList{
+		Titles: []string{"UID", "PID", "CMD"},
+		Processes: [][]string{
+			{"root", "1", "nginx: master process"},
+			{"nginx"},
+		},
+	}
+	expected := []map[string]string{
+		{"UID": "root", "PID": "1", "CMD": "nginx: master process"},
+		{"UID": "nginx", "PID": "", "CMD": ""},
+	}
+	if rows := list.Rows(); !reflect.DeepEqual(rows, expected) {
+		t.Fatalf("expected %v, got %v", expected, rows)
+	}
+}
@@ -50,6 +50,14 @@ type AuthResponse struct {
 type ContainerWaitResponse struct {
 	// StatusCode is the status code of the wait job
 	StatusCode int `json:"StatusCode"`
+	// Error is set when the wait itself failed, in which case StatusCode
+	// is not the exit code of the container.
+	Error *ContainerWaitError `json:",omitempty"`
+}
+
+// ContainerWaitError describes a failure of the wait endpoint.
+type ContainerWaitError struct {
+	Message string
 }
 
 // ContainerCommitResponse contains response of Remote API:
@@ -61,7 +69,7 @@ type ContainerCommitResponse struct {
 // ContainerChange contains response of Remote API:
 // GET "/containers/{name:.*}/changes"
 type ContainerChange struct {
-	Kind int
+	Kind ChangeType
 	Path string
 }
 
@@ -288,6 +296,7 @@ type ContainerState struct {
 	Error      string
 	StartedAt  string
 	FinishedAt string
+	Health     *Health `json:",omitempty"`
 }
 
 // ContainerNode stores information about the node that a container
@@ -393,6 +402,7 @@ type Snapshot struct {
 	Name   string
 	Volume string
 	Size   int
+	Parent string `json:",omitempty"` // Parent is the ID of the snapshot this one was taken from, if any
 }
 
 type SnapshotsListResponse struct {
@@ -406,6 +416,19 @@ type SnapshotCreateRequest struct {
 	Force  bool
 }
 
+// SnapshotInspect is the response of the snapshot inspect endpoint.
+type SnapshotInspect struct {
+	Snapshot
+}
+
+// SnapshotRestoreRequest is the request to restore a snapshot into a new
+// volume.
+type SnapshotRestoreRequest struct {
+	Snapshot string // Snapshot is the name or ID of the snapshot to restore
+	Volume   string // Volume is the name of the volume to create
+	Size     int    // Size is the size of the volume in GB, 0 to keep the size of the snapshot
+}
+
 // Volume represents the configuration of a volume for the remote API
 type Volume struct {
 	Name       string                 // Name is the name of the volume
@@ -414,10 +437,25 @@ type Volume struct {
 	Status     map[string]interface{} `json:",omitempty"` // Status provides low-level status information about the volume
 	Labels     map[string]string      // Labels is metadata specific to the volume
 	Scope      string                 // Scope describes the level at which the volume exists (e.g. `global` for cluster-wide or `local` for machine level)
+	Size       int                    `json:",omitempty"` // Size is the size of the volume in GB
 
 	CreatedAt time.Time
 }
 
+// VolumeInspect is the response of the volume inspect endpoint, with the
+// usage data of the volume when the daemon accounts for it.
+type VolumeInspect struct {
+	Volume
+	UsageData *VolumeUsageData `json:",omitempty"`
+}
+
+// VolumeUsageData holds information about the usage of a volume. Both
+// values are -1 when they are not available.
+type VolumeUsageData struct {
+	Size     int64 // Size is the space used by the volume, in bytes
+	RefCount int64 // RefCount is the number of containers referencing the volume
+}
+
 // VolumesListResponse contains the response for the remote API:
 // GET "/volumes"
 type VolumesListResponse struct {
@@ -432,6 +470,44 @@ type VolumeCreateRequest struct {
 	Driver     string            // Driver is the name of the driver that should be used to create the volume
 	DriverOpts map[string]string // DriverOpts holds the driver specific options to use for when creating the volume.
 	Labels     map[string]string // Labels holds meta data specific to the volume being created.
+	Snapshot   string            `json:",omitempty"` // Snapshot is the snapshot to provision the volume from, if any.
+}
+
+// ContainersPruneReport contains the response for the remote API:
+// POST "/containers/prune"
+type ContainersPruneReport struct {
+	ContainersDeleted []string
+	SpaceReclaimed    uint64
+}
+
+// ImagesPruneReport contains the response for the remote API:
+// POST "/images/prune"
+type ImagesPruneReport struct {
+	ImagesDeleted  []ImageDelete
+	SpaceReclaimed uint64
+}
+
+// VolumesPruneReport contains the response for the remote API:
+// POST "/volumes/prune"
+type VolumesPruneReport struct {
+	VolumesDeleted []string
+	SpaceReclaimed uint64
+}
+
+// NetworksPruneReport contains the response for the remote API:
+// POST "/networks/prune"
+type NetworksPruneReport struct {
+	NetworksDeleted []string
+}
+
+// DiskUsage contains the response for the remote API:
+// GET "/system/df"
+type DiskUsage struct {
+	LayersSize  int64
+	Images      []*Image
+	Containers  []*Container
+	Volumes     []*Volume
+	BuilderSize int64 `json:",omitempty"` // BuilderSize is the size of the build cache, if the daemon reports it
 }
 
 // VolumesInitializeResponse contains the response for the remote API:
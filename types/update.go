@@ -0,0 +1,28 @@
+package types
+
+import "github.com/hyperhq/hyper-api/types/container"
+
+// ResourceUpdateDiff returns the resources that update changes compared to
+// the current host config, keyed by field name ("Memory", "NanoCPUs" and
+// "CPUShares") with the old and new values. As with the update endpoint, a
+// zero value in update leaves the resource unchanged.
+func ResourceUpdateDiff(current *container.HostConfig, update container.UpdateConfig) map[string][2]int64 {
+	var old container.Resources
+	if current != nil {
+		old = current.Resources
+	}
+	diff := map[string][2]int64{}
+	for _, f := range []struct {
+		name     string
+		old, new int64
+	}{
+		{"Memory", old.Memory, update.Memory},
+		{"NanoCPUs", old.NanoCPUs, update.NanoCPUs},
+		{"CPUShares", old.CPUShares, update.CPUShares},
+	} {
+		if f.new != 0 && f.new != f.old {
+			diff[f.name] = [2]int64{f.old, f.new}
+		}
+	}
+	return diff
+}
@@ -0,0 +1,26 @@
+package types
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/hyperhq/hyper-api/types/container"
+)
+
+func TestResourceUpdateDiff(t *testing.T) {
+	current := &container.HostConfig{Resources: container.Resources{Memory: 512 << 20, NanoCPUs: 1e9, CPUShares: 1024}}
+	update := container.UpdateConfig{Resources: container.Resources{Memory: 1 << 30, NanoCPUs: 1e9}}
+	expected := map[string][2]int64{"Memory": {512 << 20, 1 << 30}}
+	if diff := ResourceUpdateDiff(current, update); !reflect.DeepEqual(diff, expected) {
+		t.Fatalf("expected %v, got %v", expected, diff)
+	}
+
+	if diff := ResourceUpdateDiff(current, container.UpdateConfig{}); len(diff) != 0 {
+		t.Fatalf("expected no changes, got %v", diff)
+	}
+
+	expected = map[string][2]int64{"CPUShares": {0, 512}}
+	if diff := ResourceUpdateDiff(nil, container.UpdateConfig{Resources: container.Resources{CPUShares: 512}}); !reflect.DeepEqual(diff, expected) {
+		t.Fatalf("expected %v, got %v", expected, diff)
+	}
+}
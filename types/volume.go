@@ -0,0 +1,51 @@
+package types
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// volumeIndexPlaceholder is replaced by the index of each volume in the
+// templates given to ExpandVolumeNames.
+const volumeIndexPlaceholder = "{i}"
+
+// GroupVolumesByLabel groups volumes by the value of their label. Volumes
+// without the label are grouped under "".
+func GroupVolumesByLabel(vols []*Volume, label string) map[string][]*Volume {
+	groups := map[string][]*Volume{}
+	for _, v := range vols {
+		value := v.Labels[label]
+		groups[value] = append(groups[value], v)
+	}
+	return groups
+}
+
+// TotalVolumeSizeByLabel sums the Size of the volumes by the value of
+// their label. Volumes without the label are counted under "".
+func TotalVolumeSizeByLabel(vols []*Volume, label string) map[string]int {
+	totals := map[string]int{}
+	for value, group := range GroupVolumesByLabel(vols, label) {
+		for _, v := range group {
+			totals[value] += v.Size
+		}
+	}
+	return totals
+}
+
+// ExpandVolumeNames returns count volume names built from template by
+// replacing every "{i}" with the index of the volume, starting at 1. For
+// example "data-{i}" expands to "data-1", "data-2", and so on.
+func ExpandVolumeNames(template string, count int) ([]string, error) {
+	if !strings.Contains(template, volumeIndexPlaceholder) {
+		return nil, fmt.Errorf("invalid volume name template %q: missing %s placeholder", template, volumeIndexPlaceholder)
+	}
+	if count < 1 {
+		return nil, fmt.Errorf("invalid volume count %d: must be at least 1", count)
+	}
+	names := make([]string, count)
+	for i := range names {
+		names[i] = strings.Replace(template, volumeIndexPlaceholder, strconv.Itoa(i+1), -1)
+	}
+	return names, nil
+}
@@ -0,0 +1,76 @@
+package types
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestVolumesByLabel(t *testing.T) {
+	vols := []*Volume{
+		{Name: "web-data", Size: 10, Labels: map[string]string{"team": "web"}},
+		{Name: "web-cache", Size: 5, Labels: map[string]string{"team": "web"}},
+		{Name: "db-data", Size: 50, Labels: map[string]string{"team": "db"}},
+		{Name: "scratch", Size: 1},
+	}
+	groups := GroupVolumesByLabel(vols, "team")
+	if len(groups) != 3 {
+		t.Fatalf("expected 3 groups, got %d", len(groups))
+	}
+	if len(groups["web"]) != 2 || groups["web"][0].Name != "web-data" || groups["web"][1].Name != "web-cache" {
+		t.Fatalf("unexpected web group: %v", groups["web"])
+	}
+	if len(groups[""]) != 1 || groups[""][0].Name != "scratch" {
+		t.Fatalf("expected unlabeled volumes under \"\", got %v", groups[""])
+	}
+
+	expected := map[string]int{"web": 15, "db": 50, "": 1}
+	if totals := TotalVolumeSizeByLabel(vols, "team"); !reflect.DeepEqual(totals, expected) {
+		t.Fatalf("expected %v, got %v", expected, totals)
+	}
+}
+
+func TestVolumeInspectUsageData(t *testing.T) {
+	var v VolumeInspect
+	if err := json.Unmarshal([]byte(`{"Name":"data","Driver":"hyper","Size":10}`), &v); err != nil {
+		t.Fatal(err)
+	}
+	if v.Name != "data" || v.Size != 10 || v.UsageData != nil {
+		t.Fatalf("unexpected volume: %+v", v)
+	}
+
+	if err := json.Unmarshal([]byte(`{"Name":"data","UsageData":{"Size":1073741824,"RefCount":2}}`), &v); err != nil {
+		t.Fatal(err)
+	}
+	if v.UsageData == nil || v.UsageData.Size != 1073741824 || v.UsageData.RefCount != 2 {
+		t.Fatalf("unexpected usage data: %+v", v.UsageData)
+	}
+
+	b, err := json.Marshal(VolumeInspect{Volume: Volume{Name: "data"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(string(b), "UsageData") {
+		t.Fatalf("expected usage data to be omitted, got %s", b)
+	}
+}
+
+func TestExpandVolumeNames(t *testing.T) {
+	names, err := ExpandVolumeNames("vol-{i}", 3)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if expected := []string{"vol-1", "vol-2", "vol-3"}; !reflect.DeepEqual(names, expected) {
+		t.Fatalf("expected %v, got %v", expected, names)
+	}
+	if names, err := ExpandVolumeNames("{i}-data-{i}", 1); err != nil || !reflect.DeepEqual(names, []string{"1-data-1"}) {
+		t.Fatalf("unexpected names %v, %v", names, err)
+	}
+	if _, err := ExpandVolumeNames("vol", 3); err == nil {
+		t.Fatal("expected an error without a placeholder")
+	}
+	if _, err := ExpandVolumeNames("vol-{i}", 0); err == nil {
+		t.Fatal("expected an error for a zero count")
+	}
+}
@@ -0,0 +1,62 @@
+package types
+
+// WaitResult holds the outcome of waiting on a single container, as
+// collected when waiting on several containers at once.
+type WaitResult struct {
+	ContainerID string
+	StatusCode  int
+	Err         error
+}
+
+// Failed returns true if the wait itself failed or the container exited
+// with a non-zero status code.
+func (r WaitResult) Failed() bool {
+	return r.Err != nil || r.StatusCode != 0
+}
+
+// AllSucceeded returns true if every container exited with status code 0
+// and no wait returned an error.
+func AllSucceeded(results []WaitResult) bool {
+	for _, r := range results {
+		if r.Failed() {
+			return false
+		}
+	}
+	return true
+}
+
+// FirstFailure returns the first result whose wait failed or whose
+// container exited with a non-zero status code. The boolean is false if
+// there is no such result.
+func FirstFailure(results []WaitResult) (WaitResult, bool) {
+	for _, r := range results {
+		if r.Failed() {
+			return r, true
+		}
+	}
+	return WaitResult{}, false
+}
+
+// ExitCodeHistogram counts the results per status code. Results whose
+// wait failed have no meaningful status code and are not counted.
+func ExitCodeHistogram(results []WaitResult) map[int]int {
+	histogram := map[int]int{}
+	for _, r := range results {
+		if r.Err == nil {
+			histogram[r.StatusCode]++
+		}
+	}
+	return histogram
+}
+
+// NonZeroExits returns the results of the containers that exited with a
+// non-zero status code, in order. Results whose wait failed are left out.
+func NonZeroExits(results []WaitResult) []WaitResult {
+	var failed []WaitResult
+	for _, r := range results {
+		if r.Err == nil && r.StatusCode != 0 {
+			failed = append(failed, r)
+		}
+	}
+	return failed
+}
@@ -0,0 +1,66 @@
+package types
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestAllSucceeded(t *testing.T) {
+	testCases := []struct {
+		results  []WaitResult
+		expected bool
+	}{
+		{nil, true},
+		{[]WaitResult{{ContainerID: "a"}, {ContainerID: "b"}}, true},
+		{[]WaitResult{{ContainerID: "a"}, {ContainerID: "b", StatusCode: 1}}, false},
+		{[]WaitResult{{ContainerID: "a", Err: errors.New("connection reset")}}, false},
+	}
+	for _, c := range testCases {
+		if actual := AllSucceeded(c.results); actual != c.expected {
+			t.Fatalf("expected %v for %v, got %v", c.expected, c.results, actual)
+		}
+	}
+}
+
+func TestFirstFailure(t *testing.T) {
+	results := []WaitResult{
+		{ContainerID: "a"},
+		{ContainerID: "b", StatusCode: 137},
+		{ContainerID: "c", StatusCode: 2},
+	}
+	failure, ok := FirstFailure(results)
+	if !ok {
+		t.Fatal("expected a failure to be found")
+	}
+	if failure.ContainerID != "b" || failure.StatusCode != 137 {
+		t.Fatalf("expected container b with status 137, got %+v", failure)
+	}
+
+	if _, ok := FirstFailure(results[:1]); ok {
+		t.Fatal("expected no failure among successful results")
+	}
+}
+
+func TestExitCodeHistogram(t *testing.T) {
+	results := []WaitResult{
+		{ContainerID: "a", StatusCode: 0},
+		{ContainerID: "b", StatusCode: 1},
+		{ContainerID: "c", StatusCode: 0},
+		{ContainerID: "d", StatusCode: 137},
+		{ContainerID: "e", StatusCode: 1},
+		{ContainerID: "f", Err: errors.New("no such container")},
+	}
+	expected := map[int]int{0: 2, 1: 2, 137: 1}
+	if histogram := ExitCodeHistogram(results); !reflect.DeepEqual(histogram, expected) {
+		t.Fatalf("expected %v, got %v", expected, histogram)
+	}
+
+	failed := NonZeroExits(results)
+	if len(failed) != 3 || failed[0].ContainerID != "b" || failed[1].ContainerID != "d" || failed[2].ContainerID != "e" {
+		t.Fatalf("unexpected failures: %v", failed)
+	}
+	if failed := NonZeroExits(results[:1]); failed != nil {
+		t.Fatalf("expected no failures, got %v", failed)
+	}
+}